@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BytesToAscii renders each byte as its character when it's printable or a space and as a dot
+// otherwise.
+func BytesToAscii(bytes []byte) string {
+	return bytesToAscii(bytes, true)
+}
+
+// BytesToAsciiLine is [BytesToAscii] restricted to ASCII, spaces other than ' ' (new lines, tabs)
+// and bytes above 0x7f are rendered as a dot too so the output is always a single line with one
+// ASCII character per byte.
+func BytesToAsciiLine(bytes []byte) string {
+	return bytesToAscii(bytes, false)
+}
+
+func bytesToAscii(bytes []byte, multiline bool) string {
+	builder := strings.Builder{}
+
+	for _, byteValue := range bytes {
+		character := rune(byteValue)
+
+		switch {
+		case !multiline && character >= unicode.MaxASCII:
+			builder.WriteString(".")
+
+		case unicode.IsPrint(character):
+			builder.WriteRune(character)
+
+		case multiline && unicode.IsSpace(character):
+			builder.WriteRune(character)
+
+		default:
+			builder.WriteString(".")
+		}
+	}
+
+	return builder.String()
+}
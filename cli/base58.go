@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// base58CheckChecksumLength is the length of the Base58Check checksum, the first bytes of the
+// double SHA-256 of the version prefix and the data
+const base58CheckChecksumLength = 4
+
+// DecodeBase58Check decodes the Base58Check value `in` and verifies its checksum. When `version`
+// is not empty, the decoded value must start with this version prefix which is removed from the
+// returned bytes, otherwise the returned bytes still contain the version prefix, if any.
+func DecodeBase58Check(in string, version []byte) ([]byte, error) {
+	decoded, err := base58.Decode(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) < len(version)+base58CheckChecksumLength {
+		return nil, fmt.Errorf("decoded value has %d bytes, too short to hold a %d bytes version prefix and a %d bytes checksum", len(decoded), len(version), base58CheckChecksumLength)
+	}
+
+	payload, checksum := decoded[:len(decoded)-base58CheckChecksumLength], decoded[len(decoded)-base58CheckChecksumLength:]
+	if expected := base58CheckChecksum(payload); !bytes.Equal(checksum, expected) {
+		return nil, fmt.Errorf("invalid checksum %x, expected %x", checksum, expected)
+	}
+
+	if !bytes.HasPrefix(payload, version) {
+		return nil, fmt.Errorf("version prefix %x does not match the expected version %x", payload[:len(version)], version)
+	}
+
+	return payload[len(version):], nil
+}
+
+// EncodeBase58Check encodes `version` followed by `in` with its checksum as a Base58Check value.
+func EncodeBase58Check(in []byte, version []byte) string {
+	payload := append(append([]byte{}, version...), in...)
+
+	return base58.Encode(append(payload, base58CheckChecksum(payload)...))
+}
+
+func base58CheckChecksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+
+	return second[:base58CheckChecksumLength]
+}
+
+// ParseBase58CheckVersion parses the hexadecimal version prefix `in` (e.g. `00` for Bitcoin
+// addresses, `41` for Tron addresses), its length is the version prefix length.
+func ParseBase58CheckVersion(in string) ([]byte, error) {
+	digits := strings.TrimPrefix(strings.ToLower(in), "0x")
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("version %q must have an even number of hexadecimal digits, its length being the version prefix length", in)
+	}
+
+	version, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("version %q is not valid hexadecimal: %w", in, err)
+	}
+
+	return version, nil
+}
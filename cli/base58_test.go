@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBase58Check(t *testing.T) {
+	tests := []struct {
+		in      string
+		version string
+		want    string
+		wantErr string
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "00", "77bff20c60e522dfaa3350c39b030a5d004e839a", ""},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "", "0077bff20c60e522dfaa3350c39b030a5d004e839a", ""},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "05", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb", ""},
+		{"TJRyWwFs9wTFGZg3JbrVriFbNfCug5tDeC", "41", "5cd0fb0ab3ce40f3051414c604b27756e69e43db", ""},
+
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", "00", "", "invalid checksum f415766c, expected f415766b"},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "41", "", "version prefix 00 does not match the expected version 41"},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "0078", "", "version prefix 0077 does not match the expected version 0078"},
+		{"3QJmnh", "00", "", "too short to hold a 1 bytes version prefix and a 4 bytes checksum"},
+		{"0OIl", "", "", "invalid base58 digit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in+"_"+tt.version, func(t *testing.T) {
+			version, err := ParseBase58CheckVersion(tt.version)
+			require.NoError(t, err)
+
+			got, err := DecodeBase58Check(tt.in, version)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestEncodeBase58Check(t *testing.T) {
+	version, err := ParseBase58CheckVersion("0x00")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", EncodeBase58Check(mustDecodeHex(t, "77bff20c60e522dfaa3350c39b030a5d004e839a"), version))
+
+	_, err = ParseBase58CheckVersion("041")
+	assert.EqualError(t, err, `version "041" must have an even number of hexadecimal digits, its length being the version prefix length`)
+}
+
+func mustDecodeHex(t *testing.T, in string) []byte {
+	t.Helper()
+
+	out, err := hex.DecodeString(in)
+	require.NoError(t, err)
+
+	return out
+}
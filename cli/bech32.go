@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+// Bech32Variant is the checksum variant of a bech32 value, plain Bech32 (BIP-173) or Bech32m
+// (BIP-350), they differ only by the constant used in the checksum.
+type Bech32Variant = bech32.Version
+
+const (
+	Bech32VariantBech32  = bech32.Version0
+	Bech32VariantBech32m = bech32.VersionM
+)
+
+func bech32VariantName(variant Bech32Variant) string {
+	if variant == Bech32VariantBech32m {
+		return "bech32m"
+	}
+
+	return "bech32"
+}
+
+// DecodeBech32 decodes `in` whatever its checksum variant, which is returned, and checks its
+// human readable part if `expectedHrp` is not empty. The data is returned as 5 bits groups.
+func DecodeBech32(in string, expectedHrp string) (hrp string, data []byte, variant Bech32Variant, err error) {
+	hrp, data, variant, err = bech32.DecodeGeneric(in)
+	if err != nil {
+		var checksumErr bech32.ErrInvalidChecksum
+		if errors.As(err, &checksumErr) {
+			return "", nil, variant, fmt.Errorf("invalid checksum %q, it's neither a valid bech32 nor bech32m checksum", checksumErr.Actual)
+		}
+
+		return "", nil, variant, err
+	}
+
+	if expectedHrp != "" && hrp != expectedHrp {
+		return "", nil, variant, fmt.Errorf("human readable part %q does not match the expected part %q", hrp, expectedHrp)
+	}
+
+	return hrp, data, variant, nil
+}
+
+// decodeBech32Bytes decodes `in` to bytes, `expectedVariant` is checked unless nil
+func decodeBech32Bytes(in string, expectedHrp string, expectedVariant *Bech32Variant) ([]byte, error) {
+	_, data, variant, err := DecodeBech32(in, expectedHrp)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedVariant != nil && variant != *expectedVariant {
+		return nil, fmt.Errorf("checksum is a %s checksum but %s was expected", bech32VariantName(variant), bech32VariantName(*expectedVariant))
+	}
+
+	converted, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		if _, _, segwitErr := DecodeSegWit(in, expectedHrp); segwitErr == nil {
+			return nil, fmt.Errorf("value is a SegWit address, its witness version is not part of the bytes, decode it as segwit instead")
+		}
+
+		return nil, fmt.Errorf("unable to convert bech32 data %q from 5 bits to 8 bits: %w", hex.EncodeToString(data), err)
+	}
+
+	return converted, nil
+}
+
+func encodeBech32Bytes(in []byte, hrp string, variant Bech32Variant) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("%s encoding requires a human readable part, use '%s:<hrp>'", bech32VariantName(variant), bech32VariantName(variant))
+	}
+
+	data, err := bech32.ConvertBits(in, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("unable to convert bits: %w", err)
+	}
+
+	return encodeBech32(hrp, data, variant)
+}
+
+func encodeBech32(hrp string, data []byte, variant Bech32Variant) (string, error) {
+	if variant == Bech32VariantBech32m {
+		return bech32.EncodeM(hrp, data)
+	}
+
+	return bech32.Encode(hrp, data)
+}
+
+// SegWit witness programs are represented as bytes by their output script: the witness version
+// opcode (OP_0 or OP_1 to OP_16), the program length then the program itself.
+const (
+	segwitOpcodeOne       = 0x50
+	segwitMaxVersion      = 16
+	segwitMinProgramBytes = 2
+	segwitMaxProgramBytes = 40
+)
+
+// DecodeSegWit decodes the SegWit address `in` (BIP-173 and BIP-350) to its witness version and
+// program, checking its human readable part if `expectedHrp` is not empty and that the checksum
+// variant matches the witness version, Bech32 for version 0 and Bech32m for the others.
+func DecodeSegWit(in string, expectedHrp string) (version byte, program []byte, err error) {
+	_, data, variant, err := DecodeBech32(in, expectedHrp)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("missing witness version")
+	}
+
+	version = data[0]
+	if version > segwitMaxVersion {
+		return 0, nil, fmt.Errorf("invalid witness version %d, must be between 0 and %d", version, segwitMaxVersion)
+	}
+
+	expectedVariant := segwitVariant(version)
+	if variant != expectedVariant {
+		return 0, nil, fmt.Errorf("witness version %d requires a %s checksum but got a %s one", version, bech32VariantName(expectedVariant), bech32VariantName(variant))
+	}
+
+	program, err = bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid witness program: %w", err)
+	}
+
+	if err := validateWitnessProgram(version, program); err != nil {
+		return 0, nil, err
+	}
+
+	return version, program, nil
+}
+
+// EncodeSegWit encodes the witness `version` and `program` as a SegWit address.
+func EncodeSegWit(hrp string, version byte, program []byte) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("segwit encoding requires a human readable part, use 'segwit:<hrp>'")
+	}
+
+	if version > segwitMaxVersion {
+		return "", fmt.Errorf("invalid witness version %d, must be between 0 and %d", version, segwitMaxVersion)
+	}
+
+	if err := validateWitnessProgram(version, program); err != nil {
+		return "", err
+	}
+
+	data, err := bech32.ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("unable to convert bits: %w", err)
+	}
+
+	return encodeBech32(hrp, append([]byte{version}, data...), segwitVariant(version))
+}
+
+func segwitVariant(version byte) Bech32Variant {
+	if version == 0 {
+		return Bech32VariantBech32
+	}
+
+	return Bech32VariantBech32m
+}
+
+func validateWitnessProgram(version byte, program []byte) error {
+	if len(program) < segwitMinProgramBytes || len(program) > segwitMaxProgramBytes {
+		return fmt.Errorf("invalid witness program length %d, must be between %d and %d bytes", len(program), segwitMinProgramBytes, segwitMaxProgramBytes)
+	}
+
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("invalid witness version 0 program length %d, must be 20 or 32 bytes", len(program))
+	}
+
+	return nil
+}
+
+// decodeSegWitScript decodes the SegWit address `in` to its output script bytes
+func decodeSegWitScript(in string, expectedHrp string) ([]byte, error) {
+	version, program, err := DecodeSegWit(in, expectedHrp)
+	if err != nil {
+		return nil, err
+	}
+
+	opcode := version
+	if version > 0 {
+		opcode = segwitOpcodeOne + version
+	}
+
+	return append([]byte{opcode, byte(len(program))}, program...), nil
+}
+
+// encodeSegWitScript encodes the output script `in` as a SegWit address
+func encodeSegWitScript(in []byte, hrp string) (string, error) {
+	if len(in) < 2 || (in[0] != 0 && (in[0] <= segwitOpcodeOne || in[0] > segwitOpcodeOne+segwitMaxVersion)) {
+		return "", fmt.Errorf("bytes %q are not a witness program output script, expecting the witness version opcode (OP_0 or OP_1 to OP_16), the program length and the program", hex.EncodeToString(in))
+	}
+
+	if int(in[1]) != len(in)-2 {
+		return "", fmt.Errorf("bytes %q are not a witness program output script, program length %d does not match the %d bytes received", hex.EncodeToString(in), in[1], len(in)-2)
+	}
+
+	version := in[0]
+	if version > 0 {
+		version -= segwitOpcodeOne
+	}
+
+	return EncodeSegWit(hrp, version, in[2:])
+}
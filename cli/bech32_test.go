@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from BIP-173 and BIP-350
+func TestDecodeSegWit(t *testing.T) {
+	tests := []struct {
+		address     string
+		hrp         string
+		wantVersion byte
+		wantProgram string
+		wantErr     string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "bc", 0, "751e76e8199196d454941c45d1b3a323f1433bd6", ""},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "tb", 0, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", ""},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", "bc", 1, "751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6", ""},
+		{"BC1SW50QGDZ25J", "", 16, "751e", ""},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "bc", 2, "751e76e8199196d454941c45d1b3a323", ""},
+
+		{"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut", "bc", 0, "", `human readable part "tc" does not match the expected part "bc"`},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", "bc", 0, "", "witness version 1 requires a bech32m checksum but got a bech32 one"},
+		{"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL", "bc", 0, "", "witness version 16 requires a bech32m checksum but got a bech32 one"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", "bc", 0, "", "witness version 0 requires a bech32 checksum but got a bech32m one"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", "bc", 0, "", `invalid checksum "v8f3t5", it's neither a valid bech32 nor bech32m checksum`},
+		{"bc1pw5dgrnzv", "bc", 0, "", "invalid witness program length 1, must be between 2 and 40 bytes"},
+		{"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P", "bc", 0, "", "invalid witness version 0 program length 16, must be 20 or 32 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			version, program, err := DecodeSegWit(tt.address, tt.hrp)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, version)
+			assert.Equal(t, tt.wantProgram, hex.EncodeToString(program))
+		})
+	}
+}
+
+func TestConvert_Bech32(t *testing.T) {
+	tests := []struct {
+		element string
+		from    string
+		to      string
+		want    string
+		wantErr string
+	}{
+		{"0102", "hex", "bech32m:bc", "bc1qypqn9pfur", ""},
+		{"bc1qypqn9pfur", "bech32", "hex", "0102", ""},
+		{"bc1qypqn9pfur", "bech32m:bc", "hex", "0102", ""},
+		{"cosmos1qypq36vzru", "bech32m", "hex", "", "checksum is a bech32 checksum but bech32m was expected"},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "segwit:bc", "hex", "0014751e76e8199196d454941c45d1b3a323f1433bd6", ""},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "bech32:bc", "hex", "", "value is a SegWit address, its witness version is not part of the bytes, decode it as segwit instead"},
+		{"5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6", "hex", "segwit:bc", "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", ""},
+		{"0014751e76e8199196d454941c45d1b3a323f1433bd6", "hex", "segwit:tb", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", ""},
+		{"0015751e76e8199196d454941c45d1b3a323f1433bd6", "hex", "segwit:bc", "", "program length 21 does not match the 20 bytes received"},
+		{"0102", "hex", "segwit:bc", "", "are not a witness program output script"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element+"_"+tt.from+"_"+tt.to, func(t *testing.T) {
+			got, _, err := Convert(tt.element, MustLookupCodec(tt.from), MustLookupCodec(tt.to))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
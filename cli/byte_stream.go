@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// ChunkAlignment is the number of bytes a chunk passed to a [StreamBytes] processor must
+// be a multiple of so that an encoder can encode each chunk independently and still produce
+// the same output as if the whole input was encoded at once.
+type ChunkAlignment int
+
+const (
+	// ChunkAlignmentByte can split the input anywhere, used for hexadecimal and ASCII.
+	ChunkAlignmentByte ChunkAlignment = 1
+
+	// ChunkAlignmentBase64 splits on 3 bytes boundaries, the size of a base64 quantum
+	// (3 bytes encoded as 4 characters), padding only ever happens on the last chunk.
+	ChunkAlignmentBase64 ChunkAlignment = 3
+
+	// ChunkAlignmentBase32 splits on 5 bytes boundaries, the size of a base32 quantum
+	// (5 bytes encoded as 8 characters), padding only ever happens on the last chunk.
+	ChunkAlignmentBase32 ChunkAlignment = 5
+
+	// ChunkAlignmentWhole never splits the input, the processor is called once with the
+	// full input. Required for encodings like base58 or bech32 that treat the input as a
+	// single number/checksummed payload.
+	ChunkAlignmentWhole ChunkAlignment = -1
+)
+
+const defaultStreamChunkSize = 64 * 1024
+
+// OpenByteInput opens the received files and returns a reader over their concatenated
+// content, standard input is used if no paths are provided or if a path is `-`.
+//
+// Standard input must be piped, an error is returned if it's a terminal.
+func OpenByteInput(paths []string) (io.ReadCloser, error) {
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	readers := make([]io.Reader, 0, len(paths))
+	closers := make(multiCloser, 0, len(paths))
+
+	for _, path := range paths {
+		if path == "-" {
+			fi, err := os.Stdin.Stat()
+			if err != nil {
+				closers.Close()
+				return nil, fmt.Errorf("unable to stat stdin: %w", err)
+			}
+
+			if (fi.Mode() & os.ModeCharDevice) != 0 {
+				closers.Close()
+				return nil, errors.New("standard input must be piped when reading bytes from it")
+			}
+
+			readers = append(readers, os.Stdin)
+			continue
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			closers.Close()
+			return nil, fmt.Errorf("unable to open %q: %w", path, err)
+		}
+
+		readers = append(readers, file)
+		closers = append(closers, file)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(readers...), closers}, nil
+}
+
+type multiCloser []io.Closer
+
+func (c multiCloser) Close() error {
+	var errs []error
+	for _, closer := range c {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// StreamBytes reads `reader` until the end and passes the read bytes to `processor`. Every
+// chunk received by `processor` has a length that is a multiple of `alignment` except the
+// last one which holds the remaining bytes. Chunks are at most 64 KiB so memory stays constant
+// whatever the input size, unless `alignment` is [ChunkAlignmentWhole] in which case the full
+// input is buffered and `processor` is called once.
+//
+// The context is checked between each read, its error is returned if it's done. An error
+// returned by `processor` stops the processing and is returned as is.
+func StreamBytes(ctx context.Context, reader io.Reader, alignment ChunkAlignment, processor func(chunk []byte) error) error {
+	if alignment == ChunkAlignmentWhole {
+		full := bytes.NewBuffer(nil)
+		if err := StreamBytes(ctx, reader, ChunkAlignmentByte, func(chunk []byte) error {
+			full.Write(chunk)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return processor(full.Bytes())
+	}
+
+	if alignment < 1 {
+		return fmt.Errorf("invalid chunk alignment %d", alignment)
+	}
+
+	step := int(alignment)
+	buffer := make([]byte, defaultStreamChunkSize-(defaultStreamChunkSize%step))
+	pending := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, readErr := reader.Read(buffer[pending:])
+		pending += n
+
+		if ready := pending - (pending % step); ready > 0 {
+			if err := processor(buffer[:ready]); err != nil {
+				return err
+			}
+
+			pending = copy(buffer, buffer[ready:pending])
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			return fmt.Errorf("unable to read input: %w", readErr)
+		}
+	}
+
+	if pending > 0 {
+		return processor(buffer[:pending])
+	}
+
+	return nil
+}
+
+// SliceReader returns a reader over `reader` that skips its first `skip` bytes and stops after
+// `length` bytes, a negative `length` reads until the end. Skipping past the end is not an error,
+// the returned reader is then empty.
+func SliceReader(reader io.Reader, skip int64, length int64) (io.Reader, error) {
+	if skip < 0 {
+		return nil, fmt.Errorf("invalid skip %d, must be positive", skip)
+	}
+
+	if skip > 0 {
+		if _, err := io.CopyN(io.Discard, reader, skip); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("unable to skip %d bytes: %w", skip, err)
+		}
+	}
+
+	if length >= 0 {
+		return io.LimitReader(reader, length), nil
+	}
+
+	return reader, nil
+}
+
+// ProcessInputBytes streams the bytes of the received files (or standard input if none, see
+// [OpenByteInput]) to `processor` with chunks aligned on `alignment`, see [StreamBytes]. The
+// processing stops cleanly on interrupt signal, any error exits the process.
+func ProcessInputBytes(paths []string, alignment ChunkAlignment, processor func(chunk []byte)) {
+	ProcessInputBytesSlice(paths, 0, -1, alignment, processor)
+}
+
+// ProcessInputBytesSlice is [ProcessInputBytes] over only `length` bytes of the input starting
+// at offset `skip`, see [SliceReader].
+func ProcessInputBytesSlice(paths []string, skip int64, length int64, alignment ChunkAlignment, processor func(chunk []byte)) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	input, err := OpenByteInput(paths)
+	NoError(err, "unable to open input")
+	defer input.Close()
+
+	reader, err := SliceReader(input, skip, length)
+	NoError(err, "unable to slice input")
+
+	err = StreamBytes(ctx, reader, alignment, func(chunk []byte) error {
+		processor(chunk)
+		return nil
+	})
+
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	NoError(err, "unable to process input")
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamBytes(t *testing.T) {
+	input := bytes.Repeat([]byte("0123456789"), 20_000)
+
+	tests := []struct {
+		name      string
+		reader    io.Reader
+		alignment ChunkAlignment
+		wantCalls int
+	}{
+		{"byte", bytes.NewReader(input), ChunkAlignmentByte, -1},
+		{"byte one byte reads", iotest.OneByteReader(bytes.NewReader(input[:100])), ChunkAlignmentByte, 100},
+		{"base64", bytes.NewReader(input), ChunkAlignmentBase64, -1},
+		{"base64 one byte reads", iotest.OneByteReader(bytes.NewReader(input[:100])), ChunkAlignmentBase64, 34},
+		{"base32 half reads", iotest.HalfReader(bytes.NewReader(input)), ChunkAlignmentBase32, -1},
+		{"whole", iotest.HalfReader(bytes.NewReader(input)), ChunkAlignmentWhole, 1},
+		{"empty", bytes.NewReader(nil), ChunkAlignmentBase64, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks [][]byte
+			err := StreamBytes(context.Background(), tt.reader, tt.alignment, func(chunk []byte) error {
+				chunks = append(chunks, bytes.Clone(chunk))
+				return nil
+			})
+			require.NoError(t, err)
+
+			if tt.wantCalls >= 0 {
+				assert.Len(t, chunks, tt.wantCalls)
+			}
+
+			for i, chunk := range chunks {
+				if tt.alignment == ChunkAlignmentWhole {
+					continue
+				}
+
+				assert.LessOrEqual(t, len(chunk), defaultStreamChunkSize)
+				if i < len(chunks)-1 {
+					assert.Zero(t, len(chunk)%int(tt.alignment), "chunk %d of size %d not aligned on %d", i, len(chunk), tt.alignment)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamBytes_Base64Streaming(t *testing.T) {
+	input := bytes.Repeat([]byte("abcdefg"), 50_000)
+
+	out := bytes.NewBuffer(nil)
+	err := StreamBytes(context.Background(), iotest.HalfReader(bytes.NewReader(input)), ChunkAlignmentBase64, func(chunk []byte) error {
+		out.WriteString(base64.StdEncoding.EncodeToString(chunk))
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, base64.StdEncoding.EncodeToString(input), out.String())
+}
+
+func TestStreamBytes_Errors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := StreamBytes(ctx, bytes.NewReader([]byte("abc")), ChunkAlignmentByte, func(chunk []byte) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+
+	processorErr := errors.New("processor failed")
+	err = StreamBytes(context.Background(), bytes.NewReader([]byte("abc")), ChunkAlignmentByte, func(chunk []byte) error { return processorErr })
+	assert.ErrorIs(t, err, processorErr)
+
+	err = StreamBytes(context.Background(), iotest.ErrReader(io.ErrUnexpectedEOF), ChunkAlignmentByte, func(chunk []byte) error { return nil })
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestOpenByteInput(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+	require.NoError(t, os.WriteFile(first, []byte("abc"), 0644))
+	require.NoError(t, os.WriteFile(second, []byte("def"), 0644))
+
+	reader, err := OpenByteInput([]string{first, second})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(content))
+
+	_, err = OpenByteInput([]string{first, filepath.Join(dir, "missing")})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSliceReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		skip   int64
+		length int64
+		want   string
+	}{
+		{"all", 0, -1, "0123456789"},
+		{"skip", 4, -1, "456789"},
+		{"length", 0, 3, "012"},
+		{"skip and length", 2, 3, "234"},
+		{"zero length", 2, 0, ""},
+		{"length past the end", 8, 10, "89"},
+		{"skip past the end", 20, -1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := SliceReader(iotest.HalfReader(bytes.NewReader([]byte("0123456789"))), tt.skip, tt.length)
+			require.NoError(t, err)
+
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+
+	_, err := SliceReader(bytes.NewReader(nil), -1, -1)
+	assert.EqualError(t, err, "invalid skip -1, must be positive")
+}
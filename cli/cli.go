@@ -8,12 +8,15 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/spf13/pflag"
 )
 
 var timeNow = time.Now
@@ -39,7 +42,7 @@ func NoError(err error, message string, args ...interface{}) {
 }
 
 func Quit(message string, args ...interface{}) {
-	fmt.Printf(message+"\n", args...)
+	fmt.Fprintf(os.Stderr, message+"\n", args...)
 	os.Exit(1)
 }
 
@@ -48,12 +51,12 @@ func End(message string, args ...interface{}) {
 	os.Exit(0)
 }
 
-// IsFlagSet checks if a flag is set in the [flag] package
-// by using [flag.Visit] to walk over *set* flags and return
+// IsFlagSetIn checks if a flag is set in the received flag set
+// by using [flag.FlagSet.Visit] to walk over *set* flags and return
 // true if one of them matches the provided flag name.
-func IsFlagSet(flagName string) bool {
+func IsFlagSetIn(flags *flag.FlagSet, flagName string) bool {
 	isSet := false
-	flag.Visit(func(f *flag.Flag) {
+	flags.Visit(func(f *flag.Flag) {
 		if f.Name == flagName {
 			isSet = true
 		}
@@ -88,16 +91,45 @@ type ArgumentScanner interface {
 	ScanArgument() (value string, ok bool)
 }
 
-func NewOsArgumentScanner() ArgumentScanner {
-	return NewArgumentScanner(os.Args[1:])
+// elementErrorScanner is implemented by the [ArgumentScanner] whose records can be invalid,
+// see [ScanElement].
+type elementErrorScanner interface {
+	// ElementError returns the error of the element last returned by ScanArgument, if any
+	ElementError() error
+}
+
+// ScanElement returns the next element of `scanner` like [ArgumentScanner.ScanArgument] does,
+// `err` is set when the record read is invalid (e.g. malformed JSON with `-framing jsonl`), the
+// element is then the raw record and scanning can continue with the next one.
+func ScanElement(scanner ArgumentScanner) (element string, ok bool, err error) {
+	element, ok = scanner.ScanArgument()
+	if failing, isFailing := scanner.(elementErrorScanner); ok && isFailing {
+		err = failing.ElementError()
+	}
+
+	return element, ok, err
+}
+
+// elementLineScanner is implemented by the [ArgumentScanner] reading line based inputs, see [ElementLine].
+type elementLineScanner interface {
+	// ElementLine returns the input line where the element last returned by ScanArgument starts
+	ElementLine() int
 }
 
-func NewFlagArgumentScanner() ArgumentScanner {
-	if flag.Parsed() {
-		return NewArgumentScanner(flag.Args())
+// ElementLine returns the input line (1-based) where the element last returned by `scanner`
+// starts, which differs from the element index with `-framing jsonl` (blank lines skipped) or
+// `-framing csv|tsv` (header, multi-line quoted fields). It returns 0 if the input is not line
+// based, like `-framing nul` or arguments.
+func ElementLine(scanner ArgumentScanner) int {
+	if lines, ok := scanner.(elementLineScanner); ok {
+		return lines.ElementLine()
 	}
 
-	return NewOsArgumentScanner()
+	return 0
+}
+
+func NewOsArgumentScanner() ArgumentScanner {
+	return NewArgumentScanner(os.Args[1:])
 }
 
 func NewArgumentScanner(args []string) ArgumentScanner {
@@ -105,11 +137,7 @@ func NewArgumentScanner(args []string) ArgumentScanner {
 	NoError(err, "unable to stat stdin")
 
 	if (fi.Mode() & os.ModeCharDevice) == 0 {
-		scanner := bufio.NewScanner(os.Stdin)
-		// Let's allow token as long as 50MiB
-		scanner.Buffer(nil, 50*1024*1024)
-
-		return (*bufioArgumentScanner)(scanner)
+		return newReaderArgumentScanner(os.Stdin, scanOptions)
 	}
 
 	slice := stringSliceArgumentScanner(args)
@@ -123,11 +151,7 @@ func NewStdinArgumentScanner() (ArgumentScanner, error) {
 	}
 
 	if (fi.Mode() & os.ModeCharDevice) == 0 {
-		scanner := bufio.NewScanner(os.Stdin)
-		// Let's allow token as long as 50MiB
-		scanner.Buffer(nil, 50*1024*1024)
-
-		return (*bufioArgumentScanner)(scanner), nil
+		return newReaderArgumentScanner(os.Stdin, scanOptions), nil
 	}
 
 	return nil, ErrNoStdin
@@ -139,11 +163,7 @@ func NewFileArgumentScanner(path string) (scaner ArgumentScanner, close func() e
 		return nil, nil, fmt.Errorf("open file: %w", err)
 	}
 
-	scanner := bufio.NewScanner(file)
-	// Let's allow token as long as 50MiB
-	scanner.Buffer(nil, 50*1024*1024)
-
-	return (*bufioArgumentScanner)(scanner), file.Close, nil
+	return newReaderArgumentScanner(file, scanOptions), file.Close, nil
 }
 
 type bufioArgumentScanner bufio.Scanner
@@ -186,6 +206,9 @@ func (s *stringSliceArgumentScanner) ScanArgument() (string, bool) {
 //
 // If you pass -1 to bufferSize, the whole standard input will be read and passed to the
 // `processor` function in one shot.
+//
+// Deprecated: Use [ProcessInputBytes] or [StreamBytes] which accept files, are cancellable
+// and align chunks on the encoding boundaries.
 func ProcessStandardInputBytes(bufferSize int, processor func(bytes []byte)) {
 	fi, err := os.Stdin.Stat()
 	NoError(err, "unable to stat stdin")
@@ -226,61 +249,75 @@ func ErrorUsage(usage func() string, message string, args ...interface{}) string
 	return fmt.Sprintf(message+"\n\n"+usage(), args...)
 }
 
-func SetupFlag(usage func() string) {
-	flag.CommandLine.Usage = func() {
+// SetupFlagSet sets the usage of the received flag set and parses the process arguments with it.
+func SetupFlagSet(flags *flag.FlagSet, usage func() string) {
+	flags.Usage = func() {
 		fmt.Print(usage())
 	}
-	flag.Parse()
+	flags.Parse(os.Args[1:])
 }
 
-func FlagUsage() string {
+// FlagSet is the subset of methods shared by [flag.FlagSet] and [pflag.FlagSet], it's used
+// to register flags shared across tools whether they use the standard library flags or are
+// built on cobra.
+type FlagSet interface {
+	BoolVar(p *bool, name string, value bool, usage string)
+	StringVar(p *string, name string, value string, usage string)
+	IntVar(p *int, name string, value int, usage string)
+	DurationVar(p *time.Duration, name string, value time.Duration, usage string)
+}
+
+var _ FlagSet = (*flag.FlagSet)(nil)
+var _ FlagSet = (*pflag.FlagSet)(nil)
+
+// FlagSetUsage returns the defaults of the received flag set as printed by [flag.FlagSet.PrintDefaults].
+func FlagSetUsage(flags *flag.FlagSet) string {
 	buf := bytes.NewBuffer(nil)
-	oldOutput := flag.CommandLine.Output()
-	defer func() { flag.CommandLine.SetOutput(oldOutput) }()
+	oldOutput := flags.Output()
+	defer func() { flags.SetOutput(oldOutput) }()
 
-	flag.CommandLine.SetOutput(buf)
-	flag.CommandLine.PrintDefaults()
+	flags.SetOutput(buf)
+	flags.PrintDefaults()
 
 	return buf.String()
 }
 
+// AskForConfirmation is [Confirm] that exits with an error if the user cannot be asked.
 func AskForConfirmation(message string, args ...interface{}) bool {
-	for {
-		fmt.Printf(message+" ", args...)
-		var response string
-		_, err := fmt.Scanln(&response)
-		if err != nil {
-			panic(fmt.Errorf("unable to read user confirmation, are you in an interactive terminal: %w", err))
-		}
-
-		response = strings.ToLower(strings.TrimSpace(response))
-		for _, yesResponse := range []string{"y", "yes"} {
-			if response == yesResponse {
-				return true
-			}
-		}
-
-		for _, noResponse := range []string{"n", "no"} {
-			if response == noResponse {
-				return false
-			}
-		}
+	confirmed, err := Confirm(fmt.Sprintf(message, args...))
+	NoError(err, "unable to ask for confirmation")
 
-		fmt.Println("Only Yes or No accepted, please retry!")
-		fmt.Println()
-	}
+	return confirmed
 }
 
 func ReadInteger(in string) *big.Int {
-	value := new(big.Int)
-	value, success := value.SetString(in, 10)
-	Ensure(success, "number %q is invalid", in)
+	value, err := ParseInteger(in)
+	NoError(err, "invalid integer")
 
 	return value
 }
 
+// ParseInteger parses `in` using [ParseNumber], so signed, prefixed, separated and
+// scientific notation integers are all accepted.
+func ParseInteger(in string) (*big.Int, error) {
+	return ParseNumber(in)
+}
+
 func ReadIntegerToBytes(in string) []byte {
-	return ReadInteger(in).Bytes()
+	bytes, err := ParseIntegerToBytes(in)
+	NoError(err, "invalid integer")
+
+	return bytes
+}
+
+// ParseIntegerToBytes is [ParseNumber] followed by [NumberToBytes] using the minimal width.
+func ParseIntegerToBytes(in string) ([]byte, error) {
+	value, err := ParseInteger(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return NumberToBytes(value, 0)
 }
 
 func ReadReversedInteger(in string, count int) *big.Int {
@@ -291,18 +328,35 @@ func ReadReversedInteger(in string, count int) *big.Int {
 }
 
 func ReadReversedIntegerToBytes(in string, count int) []byte {
-	bytes := ReadIntegerToBytes(in)
+	reversed, err := ParseReversedIntegerToBytes(in, count)
+	NoError(err, "invalid integer")
 
-	reversed := make([]byte, count)
-	for i := count - 1; i >= 0; i-- {
-		if len(bytes)-1 >= i {
-			reversed[count-1-i] = 0xFF ^ bytes[len(bytes)-1-i]
-		} else {
-			reversed[count-1-i] = 0xFF
-		}
+	return reversed
+}
+
+// ParseReversedIntegerToBytes parses `in` as a positive integer and returns its `count` bytes
+// big-endian representation with every bit inverted. An error is returned if the integer is
+// negative or does not fit in `count` bytes.
+func ParseReversedIntegerToBytes(in string, count int) ([]byte, error) {
+	value, err := ParseInteger(in)
+	if err != nil {
+		return nil, err
 	}
 
-	return reversed
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("value %s is negative, only positive integers can be reversed", in)
+	}
+
+	if value.BitLen() > count*8 {
+		return nil, fmt.Errorf("value %s does not fit in %d bytes", in, count)
+	}
+
+	reversed := value.FillBytes(make([]byte, count))
+	for i := range reversed {
+		reversed[i] ^= 0xFF
+	}
+
+	return reversed, nil
 }
 
 //go:generate go-enum -f=$GOFILE --marshal --names
@@ -312,6 +366,8 @@ func ReadReversedIntegerToBytes(in string, count int) []byte {
 //	None
 //	UnixSeconds
 //	UnixMilliseconds
+//	UnixMicroseconds
+//	UnixNanoseconds
 //
 // )
 type DateLikeHint uint
@@ -320,87 +376,166 @@ type DateLikeHint uint
 //
 //	Layout
 //	Timestamp
+//	Relative
 //
 // )
 type DateParsedFrom uint
 
+// ParseDateLikeInput parses `element` as a date, trying in order Unix timestamps, the
+// layouts of [DefaultDateLayouts] and relative expressions, `timezoneIfUnset` is used
+// when `element` has no timezone information. See [ExplainDateLikeInput] to know how the
+// date was parsed.
 func ParseDateLikeInput(element string, hint DateLikeHint, timezoneIfUnset *time.Location) (out time.Time, parsedFrom DateParsedFrom, ok bool) {
+	out, trace, ok := ExplainDateLikeInput(element, hint, timezoneIfUnset)
+	return out, trace.ParsedFrom, ok
+}
+
+// ExplainDateLikeInput is [ParseDateLikeInput] but also returns a [DateParseTrace] describing
+// the layout that matched, where the timezone comes from, the seconds vs milliseconds
+// decision and the date components that were filled, the trace is never nil.
+func ExplainDateLikeInput(element string, hint DateLikeHint, timezoneIfUnset *time.Location) (out time.Time, trace *DateParseTrace, ok bool) {
+	trace = &DateParseTrace{Input: element}
 	if element == "" {
-		return out, 0, false
+		return out, trace, false
 	}
 
 	if element == "now" {
-		return timeNow(), DateParsedFromLayout, true
+		trace.ParsedFrom = DateParsedFromLayout
+		trace.Zone = DateZoneSourceNow
+		return timeNow(), trace, true
 	}
 
-	if DecRegexp.MatchString(element) {
-		value, _ := strconv.ParseUint(element, 10, 64)
-
-		if hint == DateLikeHintUnixMilliseconds {
-			return fromUnixMilliseconds(value), DateParsedFromTimestamp, true
-		}
-
-		if hint == DateLikeHintUnixSeconds {
-			return fromUnixSeconds(value), DateParsedFromTimestamp, true
+	if match := epochRegexp.FindStringSubmatch(element); match != nil {
+		value, err := strconv.ParseUint(match[2], 10, 64)
+		if err != nil || value > math.MaxInt64 {
+			trace.Err = fmt.Errorf("epoch value %q is out of range", element)
+			return out, trace, false
 		}
 
-		// If the value is lower than this Unix seconds timestamp representing 3000-01-01, we assume it's a Unix seconds value
-		if value <= 32503683661 {
-			return fromUnixSeconds(value), DateParsedFromTimestamp, true
-		}
+		trace.ParsedFrom = DateParsedFromTimestamp
+		trace.Zone = DateZoneSourceUnixTimestamp
+		trace.ZoneDetail = "UTC"
 
-		// In all other cases, we assume it's a Unix milliseconds
-		return fromUnixMilliseconds(value), DateParsedFromTimestamp, true
+		unit := resolveEpochUnit(value, hint, trace)
+		return fromUnixEpoch(match[1] == "-", value, match[3], unit), trace, true
 	}
+
 	// Try all layouts we support
-	return fromLayouts(element, timezoneIfUnset)
+	if out, ok = fromLayouts(element, timezoneIfUnset, trace); ok {
+		trace.ParsedFrom = DateParsedFromLayout
+		return out, trace, true
+	}
+
+	if trace.Err != nil {
+		return out, trace, false
+	}
+
+	// Finally, try relative expressions like `now-2h`, `3 days ago` or `yesterday 15:00`
+	if out, ok = fromRelativeExpression(element, hint, timezoneIfUnset, trace); ok {
+		trace.ParsedFrom = DateParsedFromRelative
+		return out, trace, true
+	}
+
+	return out, trace, false
 }
 
-func fromLayouts(element string, timezone *time.Location) (out time.Time, parsedFrom DateParsedFrom, ok bool) {
-	for _, layout := range layouts {
-		parsed, err := time.Parse(layout, element)
+func fromLayouts(element string, timezone *time.Location, trace *DateParseTrace) (out time.Time, ok bool) {
+	registry := DefaultDateLayouts()
+
+	for _, layout := range registry.Zoned() {
+		parsed, err := time.Parse(layout.Layout, element)
 		if err == nil {
+			trace.Layout = &layout
+
 			// Fixe the timezone if it's offset is 0 which happens when using time
 			// zone abbreviations like "CET" which are not recognized by Go. Those are ambiguous,
 			// so they cannot be relied on to be parsed correctly in all cases, specific offset
 			// should be used instead.
 			name, offset := parsed.Zone()
-			if offset == 0 {
-				// Reload the location, unsure what Golang does when dealing with ambiguous
-				// timezone abbreviations like MST (Mountain Standard Time or Malaysian Standard Time).
-				// Our rules would be to use the closest timezone from America.
-				location, err := ParseTimezone(name)
-				if err != nil {
-					// Panic for now, so I get aware of cases we would need to handle differently
-					panic(fmt.Errorf("unable to load location %q: %w", name, err))
+			switch {
+			case offset == 0:
+				// Reload the location, Golang does not deal with ambiguous timezone abbreviations
+				// like MST (Mountain Standard Time or Malaysian Standard Time), those are resolved
+				// using the preferred timezone regions, see ResolveTimeZoneAbbreviation.
+				trace.Zone = DateZoneSourceInputOffset
+				location := time.UTC
+				if name != "" {
+					trace.Zone = DateZoneSourceInputAbbreviation
+					location, trace.ZoneDetail, trace.Err = resolveZoneAbbreviation(name)
+					if trace.Err != nil {
+						// The layout matched, trying other layouts would give a wrong result
+						return out, false
+					}
 				}
 
 				// Reload the time with the correct location
 				parsed = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), parsed.Nanosecond(), location)
+
+			case name == "" || strings.Contains(layout.Layout, "-07") || strings.Contains(layout.Layout, "Z07"):
+				// Go uses the local timezone when the offset matches it, so the name is not a reliable indicator
+				trace.Zone = DateZoneSourceInputOffset
+
+			default:
+				// Go resolves an abbreviation itself only when it's the one of the local timezone
+				trace.Zone = DateZoneSourceInputAbbreviation
+				trace.ZoneDetail = fmt.Sprintf("%q matches local timezone %s", name, time.Local)
 			}
 
-			return addMissingDateComponents(parsed), DateParsedFromLayout, true
+			return addMissingDateComponents(parsed, trace), true
 		}
 	}
 
-	for _, layout := range localLayouts {
-		parsed, err := time.Parse(layout, element)
+	for _, layout := range registry.Local() {
+		parsed, err := time.Parse(layout.Layout, element)
 		if err == nil {
-			return adjustBackToTimezone(parsed, timezone), DateParsedFromLayout, true
+			trace.Layout = &layout
+			trace.Zone = DateZoneSourceTimezoneIfUnset
+			trace.ZoneDetail = timezone.String()
+
+			return adjustBackToTimezone(parsed, timezone, trace), true
 		}
 	}
 
 	return
 }
 
-func addMissingDateComponents(in time.Time) time.Time {
+// resolveZoneAbbreviation resolves the zone abbreviation `name` like [ParseTimezone] does but
+// also describes how it was resolved.
+func resolveZoneAbbreviation(name string) (location *time.Location, detail string, err error) {
+	if location, err := time.LoadLocation(name); err == nil {
+		return location, fmt.Sprintf("%q loaded from timezone database", name), nil
+	}
+
+	location, detail, err = ResolveTimeZoneAbbreviation(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, offset := time.Date(2000, 1, 1, 0, 0, 0, 0, location).Zone()
+	return location, fmt.Sprintf("%q resolved to %s, %s", name, formatZoneOffset(offset), detail), nil
+}
+
+func formatZoneOffset(offsetSeconds int) string {
+	sign := "+"
+	if offsetSeconds < 0 {
+		sign = "-"
+		offsetSeconds = -offsetSeconds
+	}
+
+	return fmt.Sprintf("%s%02d:%02d", sign, offsetSeconds/3600, (offsetSeconds%3600)/60)
+}
+
+func addMissingDateComponents(in time.Time, trace *DateParseTrace) time.Time {
 	if in.Year() == 0 && in.Month() == 1 && in.Day() == 1 {
 		now := timeNow()
+		trace.FilledComponents = append(trace.FilledComponents, "year", "month", "day")
 
 		return time.Date(now.Year(), now.Month(), now.Day(), in.Hour(), in.Minute(), in.Second(), in.Nanosecond(), in.Location())
 	}
 
 	if in.Year() == 0 {
+		trace.FilledComponents = append(trace.FilledComponents, "year")
+
 		in = in.AddDate(timeNow().Year(), 0, 0)
 		return in
 	}
@@ -408,18 +543,79 @@ func addMissingDateComponents(in time.Time) time.Time {
 	return in
 }
 
-func fromUnixSeconds(value uint64) time.Time {
-	return time.Unix(int64(value), 0).UTC()
+// epochRegexp matches Unix timestamps, possibly negative (before 1970) and fractional
+var epochRegexp = regexp.MustCompile(`^(-)?([0-9]+)(?:\.([0-9]+))?$`)
+
+type epochUnit struct {
+	name      string
+	hint      DateLikeHint
+	perSecond uint64
+
+	// year3000 is 3000-01-01 expressed in this unit, the upper bound of the unit for the heuristic
+	year3000 uint64
+}
+
+var epochUnits = []epochUnit{
+	{"seconds", DateLikeHintUnixSeconds, 1, 32503683661},
+	{"milliseconds", DateLikeHintUnixMilliseconds, 1_000, 32503683661_000},
+	{"microseconds", DateLikeHintUnixMicroseconds, 1_000_000, 32503683661_000_000},
+	{"nanoseconds", DateLikeHintUnixNanoseconds, 1_000_000_000, math.MaxUint64},
 }
 
-func fromUnixMilliseconds(value uint64) time.Time {
-	ns := (int64(value) % 1000) * int64(time.Millisecond)
+// resolveEpochUnit returns the unit forced by `hint` or otherwise, the first unit in which
+// the magnitude of `value` is before year 3000.
+func resolveEpochUnit(value uint64, hint DateLikeHint, trace *DateParseTrace) epochUnit {
+	for _, unit := range epochUnits {
+		if unit.hint == hint {
+			trace.EpochUnit, trace.EpochDecision = unit.name, "forced by hint"
+			return unit
+		}
+	}
+
+	for i, unit := range epochUnits {
+		if value <= unit.year3000 {
+			trace.EpochUnit = unit.name
+			if i == 0 {
+				trace.EpochDecision = fmt.Sprintf("heuristic, value is lower or equal to %d (year 3000 in seconds)", unit.year3000)
+			} else {
+				trace.EpochDecision = fmt.Sprintf("heuristic, value is greater than %d (year 3000 in %s)", epochUnits[i-1].year3000, epochUnits[i-1].name)
+			}
+
+			return unit
+		}
+	}
 
-	return time.Unix(int64(value)/1000, ns).UTC()
+	panic("unreachable, nanoseconds accept all values")
 }
 
-func adjustBackToTimezone(in time.Time, timezone *time.Location) time.Time {
-	in = addMissingDateComponents(in)
+// fromUnixEpoch converts `value` expressed in `unit` to time, `fraction` are the digits
+// after the decimal point, if any, precision below the nanosecond is dropped.
+func fromUnixEpoch(negative bool, value uint64, fraction string, unit epochUnit) time.Time {
+	nanosPerUnit := uint64(time.Second) / unit.perSecond
+
+	seconds := int64(value / unit.perSecond)
+	nanos := int64((value % unit.perSecond) * nanosPerUnit)
+
+	if fraction != "" {
+		// Number of fraction digits representable in nanoseconds for this unit (9 for seconds, 0 for nanoseconds)
+		digits := len(strconv.FormatUint(nanosPerUnit, 10)) - 1
+		fraction = (fraction + strings.Repeat("0", digits))[:digits]
+
+		if fraction != "" {
+			fractionNanos, _ := strconv.ParseInt(fraction, 10, 64)
+			nanos += fractionNanos
+		}
+	}
+
+	if negative {
+		return time.Unix(-seconds, -nanos).UTC()
+	}
+
+	return time.Unix(seconds, nanos).UTC()
+}
+
+func adjustBackToTimezone(in time.Time, timezone *time.Location, trace *DateParseTrace) time.Time {
+	in = addMissingDateComponents(in, trace)
 
 	if in.Location() == time.UTC {
 		adjusted := in.In(timezone)
@@ -454,16 +650,23 @@ func ParseTimezone(value string) (*time.Location, error) {
 	location, err := time.LoadLocation(value)
 	if err != nil {
 		// Check if it's a location abbreviation we know about
-		if location, found := GetTimeZoneAbbreviationLocation(value); found {
+		location, _, abbreviationErr := ResolveTimeZoneAbbreviation(value)
+		if abbreviationErr == nil {
 			return location, nil
 		}
 
+		if !errors.Is(abbreviationErr, ErrUnknownTimeZoneAbbreviation) {
+			return nil, abbreviationErr
+		}
+
 		return nil, fmt.Errorf("invalid timezone %q: %w", value, err)
 	}
 
 	return location, nil
 }
 
+// layouts are the built-in zoned layouts, see [DateLayoutRegistry] to add layouts
+// without changing the code.
 var layouts = []string{
 	// Sorted from most probably to less probably
 	time.RFC3339,
@@ -512,6 +715,8 @@ var layouts = []string{
 	"2006-01-02 15:04:05 UTC",
 }
 
+// localLayouts are the built-in local layouts, see [DateLayoutRegistry] to add layouts
+// without changing the code.
 var localLayouts = []string{
 	// Seen on some websites
 	"Jan-02-2006 15:04:05 PM",
@@ -524,6 +729,9 @@ var localLayouts = []string{
 
 	// Variation of non-local version, see in `layouts` list
 	"Mon Jan 02 15:04:05 2006",
+
+	// ISO-8601 date only, resolves to midnight, mostly used as base of relative expressions like `2024-05-01+36h`
+	"2006-01-02",
 }
 
 // TimeOnlyLayouts are layouts that only contain time components (no date).
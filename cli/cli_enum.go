@@ -15,14 +15,20 @@ const (
 	DateLikeHintUnixSeconds
 	// DateLikeHintUnixMilliseconds is a DateLikeHint of type UnixMilliseconds.
 	DateLikeHintUnixMilliseconds
+	// DateLikeHintUnixMicroseconds is a DateLikeHint of type UnixMicroseconds.
+	DateLikeHintUnixMicroseconds
+	// DateLikeHintUnixNanoseconds is a DateLikeHint of type UnixNanoseconds.
+	DateLikeHintUnixNanoseconds
 )
 
-const _DateLikeHintName = "NoneUnixSecondsUnixMilliseconds"
+const _DateLikeHintName = "NoneUnixSecondsUnixMillisecondsUnixMicrosecondsUnixNanoseconds"
 
 var _DateLikeHintNames = []string{
 	_DateLikeHintName[0:4],
 	_DateLikeHintName[4:15],
 	_DateLikeHintName[15:31],
+	_DateLikeHintName[31:47],
+	_DateLikeHintName[47:62],
 }
 
 // DateLikeHintNames returns a list of possible string values of DateLikeHint.
@@ -36,6 +42,8 @@ var _DateLikeHintMap = map[DateLikeHint]string{
 	0: _DateLikeHintName[0:4],
 	1: _DateLikeHintName[4:15],
 	2: _DateLikeHintName[15:31],
+	3: _DateLikeHintName[31:47],
+	4: _DateLikeHintName[47:62],
 }
 
 // String implements the Stringer interface.
@@ -50,6 +58,8 @@ var _DateLikeHintValue = map[string]DateLikeHint{
 	_DateLikeHintName[0:4]:   0,
 	_DateLikeHintName[4:15]:  1,
 	_DateLikeHintName[15:31]: 2,
+	_DateLikeHintName[31:47]: 3,
+	_DateLikeHintName[47:62]: 4,
 }
 
 // ParseDateLikeHint attempts to convert a string to a DateLikeHint
@@ -81,13 +91,16 @@ const (
 	DateParsedFromLayout DateParsedFrom = iota
 	// DateParsedFromTimestamp is a DateParsedFrom of type Timestamp.
 	DateParsedFromTimestamp
+	// DateParsedFromRelative is a DateParsedFrom of type Relative.
+	DateParsedFromRelative
 )
 
-const _DateParsedFromName = "LayoutTimestamp"
+const _DateParsedFromName = "LayoutTimestampRelative"
 
 var _DateParsedFromNames = []string{
 	_DateParsedFromName[0:6],
 	_DateParsedFromName[6:15],
+	_DateParsedFromName[15:23],
 }
 
 // DateParsedFromNames returns a list of possible string values of DateParsedFrom.
@@ -100,6 +113,7 @@ func DateParsedFromNames() []string {
 var _DateParsedFromMap = map[DateParsedFrom]string{
 	0: _DateParsedFromName[0:6],
 	1: _DateParsedFromName[6:15],
+	2: _DateParsedFromName[15:23],
 }
 
 // String implements the Stringer interface.
@@ -111,8 +125,9 @@ func (x DateParsedFrom) String() string {
 }
 
 var _DateParsedFromValue = map[string]DateParsedFrom{
-	_DateParsedFromName[0:6]:  0,
-	_DateParsedFromName[6:15]: 1,
+	_DateParsedFromName[0:6]:   0,
+	_DateParsedFromName[6:15]:  1,
+	_DateParsedFromName[15:23]: 2,
 }
 
 // ParseDateParsedFrom attempts to convert a string to a DateParsedFrom
@@ -268,3 +268,66 @@ func Test_ParseTimeOnlyInput(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseDateLikeInput_Epoch(t *testing.T) {
+	tests := []struct {
+		element  string
+		hint     DateLikeHint
+		want     string
+		wantUnit string
+	}{
+		{"1700000000", DateLikeHintNone, "2023-11-14T22:13:20Z", "seconds"},
+		{"1700000000123", DateLikeHintNone, "2023-11-14T22:13:20.123Z", "milliseconds"},
+		{"1700000000123456", DateLikeHintNone, "2023-11-14T22:13:20.123456Z", "microseconds"},
+		{"1700000000123456789", DateLikeHintNone, "2023-11-14T22:13:20.123456789Z", "nanoseconds"},
+		{"1700000000.123456", DateLikeHintNone, "2023-11-14T22:13:20.123456Z", "seconds"},
+		{"1700000000123.5", DateLikeHintNone, "2023-11-14T22:13:20.1235Z", "milliseconds"},
+		{"-86400", DateLikeHintNone, "1969-12-31T00:00:00Z", "seconds"},
+		{"-1.5", DateLikeHintNone, "1969-12-31T23:59:58.5Z", "seconds"},
+		{"1700000000", DateLikeHintUnixMilliseconds, "1970-01-20T16:13:20Z", "milliseconds"},
+		{"1700000000", DateLikeHintUnixMicroseconds, "1970-01-01T00:28:20Z", "microseconds"},
+		{"1700000000", DateLikeHintUnixNanoseconds, "1970-01-01T00:00:01.7Z", "nanoseconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element+" "+tt.hint.String(), func(t *testing.T) {
+			out, trace, ok := ExplainDateLikeInput(tt.element, tt.hint, testLocation)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.want, out.Format(time.RFC3339Nano))
+			assert.Equal(t, DateParsedFromTimestamp, trace.ParsedFrom)
+			assert.Equal(t, tt.wantUnit, trace.EpochUnit)
+		})
+	}
+
+	_, _, ok := ExplainDateLikeInput("99999999999999999999", DateLikeHintNone, testLocation)
+	assert.False(t, ok)
+}
+
+func TestParseReversedIntegerToBytes(t *testing.T) {
+	tests := []struct {
+		in      string
+		count   int
+		want    []byte
+		wantErr string
+	}{
+		{"5", 4, []byte{0xff, 0xff, 0xff, 0xfa}, ""},
+		{"0x01020304", 4, []byte{0xfe, 0xfd, 0xfc, 0xfb}, ""},
+		{"5", 8, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfa}, ""},
+		{"-5", 4, nil, "value -5 is negative, only positive integers can be reversed"},
+		{"99999999999", 4, nil, "value 99999999999 does not fit in 4 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseReversedIntegerToBytes(tt.in, tt.count)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package cli
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// Codec converts bytes from and to one of their textual representations, see [LookupCodec].
+type Codec struct {
+	Name string
+
+	// Param is the parameter received in the codec specification (e.g. the human readable
+	// part of `bech32:cosmos`), empty if none.
+	Param string
+
+	definition *codecDefinition
+}
+
+type codecDefinition struct {
+	name        string
+	aliases     []string
+	description string
+	alignment   ChunkAlignment
+	decode      func(in string, param string) ([]byte, error)
+	encode      func(in []byte, param string) (string, error)
+
+	// hasParam is true if the codec accepts a parameter in its specification, which is checked
+	// by validateParam when set
+	hasParam      bool
+	validateParam func(param string) error
+}
+
+// codecs are all the codecs of the registry, in the order they are listed to the user
+var codecs = []*codecDefinition{
+	{
+		name:        "hex",
+		description: "hexadecimal, 0x prefix accepted when decoding",
+		alignment:   ChunkAlignmentByte,
+		decode:      func(in string, _ string) ([]byte, error) { return DecodeHex(in) },
+		encode:      func(in []byte, _ string) (string, error) { return EncodeHex(in), nil },
+	},
+	{
+		name:        "integer",
+		aliases:     []string{"dec", "int"},
+		description: "integer, signed, 0x/0o/0b prefixed, 1_000 separated or 1e18 scientific notation accepted when decoding, -width applies",
+		alignment:   ChunkAlignmentWhole,
+		decode:      func(in string, _ string) ([]byte, error) { return ParseIntegerToBytesFromFlags(in) },
+		encode:      func(in []byte, _ string) (string, error) { return new(big.Int).SetBytes(in).String(), nil },
+	},
+	{
+		name:        "base58",
+		aliases:     []string{"b58"},
+		description: "base58 using the Bitcoin alphabet",
+		alignment:   ChunkAlignmentWhole,
+		decode:      func(in string, _ string) ([]byte, error) { return base58.Decode(in) },
+		encode:      func(in []byte, _ string) (string, error) { return base58.Encode(in), nil },
+	},
+	{
+		name:          "base58check",
+		aliases:       []string{"b58c"},
+		description:   "Base58Check, base58 with a 4 bytes double SHA-256 checksum, in the form base58check:<version> where the optional version prefix is in hex (e.g. 00 for Bitcoin, 41 for Tron addresses), its length being the version prefix length, the version is checked and removed when decoding and added when encoding, without it the version is part of the bytes",
+		alignment:     ChunkAlignmentWhole,
+		hasParam:      true,
+		validateParam: func(param string) error { _, err := ParseBase58CheckVersion(param); return err },
+		decode: func(in string, param string) ([]byte, error) {
+			version, err := ParseBase58CheckVersion(param)
+			if err != nil {
+				return nil, err
+			}
+
+			return DecodeBase58Check(in, version)
+		},
+		encode: func(in []byte, param string) (string, error) {
+			version, err := ParseBase58CheckVersion(param)
+			if err != nil {
+				return "", err
+			}
+
+			return EncodeBase58Check(in, version), nil
+		},
+	},
+	base64Codec("base64", []string{"b64"}, "base64 standard alphabet, padded", base64.StdEncoding),
+	base64Codec("base64raw", nil, "base64 standard alphabet, not padded", base64.RawStdEncoding),
+	base64Codec("base64url", []string{"b64u"}, "base64 URL safe alphabet, padded", base64.URLEncoding),
+	base64Codec("base64urlraw", nil, "base64 URL safe alphabet, not padded", base64.RawURLEncoding),
+	{
+		name:        "bech32",
+		description: "bech32 or bech32m when decoding (variant detected from the checksum), bech32 when encoding, in the form bech32:<hrp>, the human readable part is required to encode and checked when decoding if provided",
+		alignment:   ChunkAlignmentWhole,
+		hasParam:    true,
+		decode:      func(in string, hrp string) ([]byte, error) { return decodeBech32Bytes(in, hrp, nil) },
+		encode:      func(in []byte, hrp string) (string, error) { return encodeBech32Bytes(in, hrp, Bech32VariantBech32) },
+	},
+	{
+		name:        "bech32m",
+		description: "bech32m (BIP-350), in the form bech32m:<hrp>, decoding fails on a plain bech32 checksum",
+		alignment:   ChunkAlignmentWhole,
+		hasParam:    true,
+		decode: func(in string, hrp string) ([]byte, error) {
+			variant := Bech32VariantBech32m
+			return decodeBech32Bytes(in, hrp, &variant)
+		},
+		encode: func(in []byte, hrp string) (string, error) { return encodeBech32Bytes(in, hrp, Bech32VariantBech32m) },
+	},
+	{
+		name:        "segwit",
+		description: "SegWit address, in the form segwit:<hrp>, as the witness program output script bytes: the witness version opcode (00 or 51 to 60), the program length and the program",
+		alignment:   ChunkAlignmentWhole,
+		hasParam:    true,
+		decode:      decodeSegWitScript,
+		encode:      encodeSegWitScript,
+	},
+	{
+		name:        "string",
+		aliases:     []string{"str", "s"},
+		description: "the raw bytes of the string",
+		alignment:   ChunkAlignmentByte,
+		decode:      func(in string, _ string) ([]byte, error) { return []byte(in), nil },
+		encode:      func(in []byte, _ string) (string, error) { return string(in), nil },
+	},
+}
+
+// base64Codec defines a base64 codec encoding with `encoding`, padding is optional when decoding
+// so that padded and raw values are both accepted whatever the variant
+func base64Codec(name string, aliases []string, description string, encoding *base64.Encoding) *codecDefinition {
+	return &codecDefinition{
+		name:        name,
+		aliases:     aliases,
+		description: description,
+		alignment:   ChunkAlignmentBase64,
+		decode: func(in string, _ string) ([]byte, error) {
+			return encoding.WithPadding(base64.NoPadding).DecodeString(strings.TrimRight(in, "="))
+		},
+		encode: func(in []byte, _ string) (string, error) {
+			return encoding.EncodeToString(in), nil
+		},
+	}
+}
+
+// LookupCodec returns the codec of the registry matching `spec`, which is a codec name or alias
+// optionally followed by `:<param>` (e.g. `hex`, `b64u` or `bech32:cosmos`).
+func LookupCodec(spec string) (*Codec, error) {
+	name, param, _ := strings.Cut(spec, ":")
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, definition := range codecs {
+		if definition.name == name || indexOf(definition.aliases, name) != -1 {
+			if param != "" && !definition.hasParam {
+				return nil, fmt.Errorf("codec %q does not accept a parameter", definition.name)
+			}
+
+			if param != "" && definition.validateParam != nil {
+				if err := definition.validateParam(param); err != nil {
+					return nil, fmt.Errorf("invalid %s parameter: %w", definition.name, err)
+				}
+			}
+
+			return &Codec{Name: definition.name, Param: param, definition: definition}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown codec %q, valid codecs are %s", spec, strings.Join(CodecNames(), ", "))
+}
+
+// MustLookupCodec is [LookupCodec] but panics on error, meant for codecs known at compile time.
+func MustLookupCodec(spec string) *Codec {
+	codec, err := LookupCodec(spec)
+	if err != nil {
+		panic(err)
+	}
+
+	return codec
+}
+
+// CodecNames returns the name of all the codecs of the registry.
+func CodecNames() []string {
+	names := make([]string, len(codecs))
+	for i, definition := range codecs {
+		names[i] = definition.name
+	}
+
+	return names
+}
+
+// CodecsUsage returns one line per codec of the registry with its aliases and description.
+func CodecsUsage() string {
+	lines := make([]string, len(codecs))
+	for i, definition := range codecs {
+		name := definition.name
+		if len(definition.aliases) > 0 {
+			name += " (" + strings.Join(definition.aliases, ", ") + ")"
+		}
+
+		lines[i] = fmt.Sprintf("  %-24s %s", name, definition.description)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Decode returns the bytes represented by `in`.
+func (c *Codec) Decode(in string) ([]byte, error) {
+	out, err := c.definition.decode(in, c.Param)
+	if err != nil {
+		return nil, fmt.Errorf("value %q is not a valid %s value: %w", in, c.Name, err)
+	}
+
+	return out, nil
+}
+
+// Encode returns the representation of `in`.
+func (c *Codec) Encode(in []byte) (string, error) {
+	return c.definition.encode(in, c.Param)
+}
+
+// Alignment is the chunk alignment to use with [StreamBytes] when encoding a bytes stream.
+func (c *Codec) Alignment() ChunkAlignment {
+	return c.definition.alignment
+}
+
+// InferCodec returns the codec of `element` for tools converting without an explicit input
+// codec: a value wrapped with `"` is a string (returned unwrapped) and a value made only of
+// hexadecimal characters is hex. It returns false if the representation cannot be inferred.
+func InferCodec(element string) (codec *Codec, value string, ok bool) {
+	if len(element) >= 2 && element[0] == '"' && element[len(element)-1] == '"' {
+		return MustLookupCodec("string"), element[1 : len(element)-1], true
+	}
+
+	if HexRegexp.MatchString(element) {
+		return MustLookupCodec("hex"), element, true
+	}
+
+	return nil, element, false
+}
+
+// Convert decodes `element` with `from` and encodes the bytes with `to`, see [DecodeElement].
+// The kind returned is the name of the decoding codec. When `from` is nil and the codec inferred
+// is `to` itself, the element is rejected as its representation is ambiguous: converting it would
+// return it unchanged while it was most likely meant in another representation.
+func Convert(element string, from *Codec, to *Codec) (out string, kind string, err error) {
+	if from == nil {
+		inferred, value, ok := InferCodec(element)
+		if !ok || inferred.Name == to.Name {
+			return "", "", errUnableToInfer
+		}
+
+		from, element = inferred, value
+	}
+
+	bytes, kind, err := DecodeElement(element, from)
+	if err != nil {
+		return "", kind, err
+	}
+
+	out, err = to.Encode(bytes)
+	return out, kind, err
+}
+
+var errUnableToInfer = errors.New("unable to infer content's actual representation, specify one of -from <codec>, -hex (hexadecimal), -b58 (base58), -b58c (base58check), -b64 (base64 std), -b64u (base64 URL), -bech32 <hrp> (bech32), -segwit <hrp> (SegWit), -i (integer), -s (string)")
+
+// DecodeElement decodes `element` with `from`, which is inferred with [InferCodec] when nil.
+// The kind returned is the name of the decoding codec.
+func DecodeElement(element string, from *Codec) (out []byte, kind string, err error) {
+	if from == nil {
+		var ok bool
+		if from, element, ok = InferCodec(element); !ok {
+			return nil, "", errUnableToInfer
+		}
+	}
+
+	out, err = from.Decode(element)
+	return out, from.Name, err
+}
+
+var codecFlags = struct {
+	from         string
+	hex          bool
+	base58       bool
+	check        bool
+	checkVersion string
+	base64       bool
+	url          bool
+	bech32       string
+	m            string
+	segwit       string
+	dec          bool
+	str          bool
+}{}
+
+// RegisterCodecFlags registers the shared input codec flags on the received flag set, `-from`
+// accepting any codec of the registry and its shorthands `-hex`, `-b58`, `-b58c` (with
+// `-b58c-version <hex>`), `-b64`, `-b64u`,
+// `-bech32 <hrp>`, `-bech32m <hrp>`, `-segwit <hrp>`, `-i` and `-s`, see [CodecFromFlags].
+func RegisterCodecFlags(flags *flag.FlagSet) {
+	flags.StringVar(&codecFlags.from, "from", "", fmt.Sprintf("Decode the input using this codec, one of %s, bech32, bech32m and segwit accept the expected human readable part as <codec>:<hrp>", strings.Join(CodecNames(), ", ")))
+	flags.BoolVar(&codecFlags.hex, "hex", false, "Decode the input as an hexadecimal representation, same as -from hex")
+	flags.BoolVar(&codecFlags.base58, "b58", false, "Decode the input as a base58 representation, same as -from base58")
+	flags.BoolVar(&codecFlags.check, "b58c", false, "Decode the input as a Base58Check representation verifying its checksum, same as -from base58check")
+	flags.StringVar(&codecFlags.checkVersion, "b58c-version", "", "With -b58c, the expected version prefix in hex (e.g. 00 for Bitcoin, 41 for Tron addresses) removed from the decoded bytes, its length is the version prefix length, same as -from base58check:<version>")
+	flags.BoolVar(&codecFlags.base64, "b64", false, "Decode the input as a standard base64 representation (padding optional), same as -from base64")
+	flags.BoolVar(&codecFlags.url, "b64u", false, "Decode the input as URL base64 representation (padding optional), same as -from base64url")
+	flags.Func("bech32", "Decode the input as a bech32 or bech32m representation with the value being the human readable part, same as -from bech32:<hrp>", humanReadablePartFlag(&codecFlags.bech32, "bech32"))
+	flags.Func("bech32m", "Decode the input as a bech32m representation only with the value being the human readable part, same as -from bech32m:<hrp>", humanReadablePartFlag(&codecFlags.m, "bech32m"))
+	flags.Func("segwit", "Decode the input as a SegWit address with the value being the human readable part (e.g. bc), the bytes are the witness program output script, same as -from segwit:<hrp>", humanReadablePartFlag(&codecFlags.segwit, "segwit"))
+	flags.BoolVar(&codecFlags.dec, "i", false, "Decode the input as an integer representation (signed, 0x/0o/0b prefixed, 1_000 separated or 1e18 scientific notation), same as -from integer")
+	flags.BoolVar(&codecFlags.str, "s", false, "Decode the string and not it's representation, same as -from string")
+}
+
+// humanReadablePartFlag sets `target` to the flag value, rejecting an empty value which would
+// otherwise be indistinguishable from the flag not being set
+func humanReadablePartFlag(target *string, flag string) func(string) error {
+	return func(value string) error {
+		if value == "" {
+			return fmt.Errorf("flag -%s requires a value to be provided like '-%s=hrp' where 'hrp' is the human readable part of the %s value", flag, flag, flag)
+		}
+
+		*target = value
+		return nil
+	}
+}
+
+// EnsureNoCodecFlag exits with an error if `inputCodec`, as returned by [CodecFromFlags], was
+// selected while `flag`, which is exclusive with the input codec flags, is used.
+func EnsureNoCodecFlag(inputCodec *Codec, flag string) {
+	Ensure(inputCodec == nil, "Flag %s is exclusive and cannot be used at the same time as any of -from, -hex, -b58, -b58c, -b64, -b64u, -bech32, -bech32m, -segwit, -i nor -s", flag)
+}
+
+// CodecFromFlags returns the input codec selected by the flags of [RegisterCodecFlags], nil if
+// none was selected meaning that the input codec should be inferred, see [InferCodec].
+func CodecFromFlags() (*Codec, error) {
+	var specs []string
+	for _, candidate := range []struct {
+		spec     string
+		selected bool
+	}{
+		{codecFlags.from, codecFlags.from != ""},
+		{"hex", codecFlags.hex},
+		{"base58", codecFlags.base58},
+		{"base58check:" + codecFlags.checkVersion, codecFlags.check},
+		{"base64", codecFlags.base64},
+		{"base64url", codecFlags.url},
+		{"bech32:" + codecFlags.bech32, codecFlags.bech32 != ""},
+		{"bech32m:" + codecFlags.m, codecFlags.m != ""},
+		{"segwit:" + codecFlags.segwit, codecFlags.segwit != ""},
+		{"integer", codecFlags.dec},
+		{"string", codecFlags.str},
+	} {
+		if candidate.selected {
+			specs = append(specs, candidate.spec)
+		}
+	}
+
+	if codecFlags.checkVersion != "" && !codecFlags.check {
+		return nil, fmt.Errorf("flag -b58c-version can only be used with -b58c")
+	}
+
+	switch len(specs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return LookupCodec(specs[0])
+	default:
+		return nil, fmt.Errorf("only one input codec can be selected, got %s", strings.Join(specs, ", "))
+	}
+}
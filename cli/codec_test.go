@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		element  string
+		from     string
+		to       string
+		want     string
+		wantKind string
+		wantErr  string
+	}{
+		{"abfe0102", "", "base58", "5PzCau", "hex", ""},
+		{`"myname"`, "", "hex", "6d796e616d65", "string", ""},
+		{"0xABFE0102", "hex", "base64", "q/4BAg==", "hex", ""},
+		{"q/4BAg==", "b64", "hex", "abfe0102", "base64", ""},
+		{"q/4BAg", "base64", "hex", "abfe0102", "base64", ""},
+		{"q_4BAg==", "b64u", "hex", "abfe0102", "base64url", ""},
+		{"q_4BAg", "base64urlraw", "base64", "q/4BAg==", "base64urlraw", ""},
+		{"abfe0102ff", "hex", "base64raw", "q/4BAv8", "hex", ""},
+		{"abfe0102ff", "hex", "base64url", "q_4BAv8=", "hex", ""},
+		{"5PzCau", "b58", "dec", "2885550338", "base58", ""},
+		{"126700", "dec", "hex", "01eeec", "integer", ""},
+		{"1e18", "integer", "hex", "0de0b6b3a7640000", "integer", ""},
+		{"0102", "hex", "bech32:cosmos", "cosmos1qypq36vzru", "hex", ""},
+		{"cosmos1qypq36vzru", "bech32:cosmos", "hex", "0102", "bech32", ""},
+		{"cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "bech32", "bech32:sei", "sei1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5jwagqa", "bech32", ""},
+		{"6869", "hex", "string", "hi", "hex", ""},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "b58c:00", "hex", "77bff20c60e522dfaa3350c39b030a5d004e839a", "base58check", ""},
+		{"TJRyWwFs9wTFGZg3JbrVriFbNfCug5tDeC", "base58check:41", "base58check:00", "19TmWst91DLJ7PcQiLCnLZZTTsTbVXJhLk", "base58check", ""},
+		{"5cd0fb0ab3ce40f3051414c604b27756e69e43db", "hex", "b58c:41", "TJRyWwFs9wTFGZg3JbrVriFbNfCug5tDeC", "hex", ""},
+
+		{"zz", "", "hex", "", "", "unable to infer content's actual representation"},
+		{"1234", "", "hex", "", "", "unable to infer content's actual representation"},
+		{"@@", "b64", "hex", "", "base64", `value "@@" is not a valid base64 value: illegal base64 data at input byte 0`},
+		{"0OIl", "b58", "hex", "", "base58", `value "0OIl" is not a valid base58 value`},
+		{"cosmos1qypq36vzru", "bech32:sei", "hex", "", "bech32", `human readable part "cosmos" does not match the expected part "sei"`},
+		{"0102", "hex", "bech32", "", "hex", "bech32 encoding requires a human readable part"},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", "b58c", "hex", "", "base58check", "invalid checksum f415766c, expected f415766b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element+"_"+tt.from+"_"+tt.to, func(t *testing.T) {
+			var from *Codec
+			if tt.from != "" {
+				from = MustLookupCodec(tt.from)
+			}
+
+			got, kind, err := Convert(tt.element, from, MustLookupCodec(tt.to))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantKind, kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+}
+
+func TestLookupCodec(t *testing.T) {
+	codec, err := LookupCodec("B64U")
+	require.NoError(t, err)
+	assert.Equal(t, "base64url", codec.Name)
+
+	codec, err = LookupCodec("bech32:cosmos")
+	require.NoError(t, err)
+	assert.Equal(t, "bech32", codec.Name)
+	assert.Equal(t, "cosmos", codec.Param)
+
+	_, err = LookupCodec("hex:cosmos")
+	assert.EqualError(t, err, `codec "hex" does not accept a parameter`)
+
+	_, err = LookupCodec("base32")
+	assert.EqualError(t, err, `unknown codec "base32", valid codecs are hex, integer, base58, base58check, base64, base64raw, base64url, base64urlraw, bech32, bech32m, segwit, string`)
+}
@@ -0,0 +1,467 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configEnviron is overridden in tests to control the environment seen by [ConfigLoader.Load]
+var configEnviron = os.Environ
+
+// ConfigLoader loads the configuration of a tool by layering, from lowest to highest precedence,
+// the defaults, the YAML config file, the environment variables and the `--set` flag values.
+//
+// The config file is `<Tool>.yaml` in the tooling config directory (see [DefaultToolingConfigFile])
+// unless overridden by the `--config` flag or the `SFTOOL_<TOOL>_CONFIG` environment variable.
+// Each scalar key reachable through structs is also read from the environment variable
+// `SFTOOL_<TOOL>_<KEY>` where `<KEY>` is the dotted key path upper-cased with `.` replaced
+// by `_` (e.g. `after_bump.go_mod_tidy` is `SFTOOL_GO_BUMP_AFTER_BUMP_GO_MOD_TIDY`).
+//
+// Decoding is strict, unknown keys in the file, unknown `SFTOOL_<TOOL>_*` environment variables
+// and unknown `--set` keys are all reported as errors, with a suggestion when a known key is close.
+type ConfigLoader[T any] struct {
+	// Tool is the name of the tool owning the config, like `go_replace`
+	Tool string
+
+	// LegacyFiles are config file locations, relative to the user home directory, read when
+	// the default config file does not exist
+	LegacyFiles []string
+
+	// Default returns the config before any source is applied, a zero value is used if nil
+	Default func() *T
+
+	// Validate checks the config once all sources have been applied, optional
+	Validate func(config *T) error
+}
+
+// ConfigOverrides are the config sources coming from the command line, see [RegisterConfigFlags].
+type ConfigOverrides struct {
+	// File is the config file to read instead of the default one, empty means the default one
+	File string
+
+	// Sets are `<key>=<value>` entries applied over all other sources
+	Sets []string
+}
+
+// LoadedConfig is the result of [ConfigLoader.Load].
+type LoadedConfig[T any] struct {
+	Config *T
+
+	// File is the config file that was read, or that would have been read if it existed
+	File       string
+	FileExists bool
+
+	// Origins maps the key paths set by environment variables or `--set` flags to a
+	// description of the source that set them
+	Origins map[string]string
+}
+
+// EnvPrefix returns the prefix of the environment variables read by the loader, `SFTOOL_<TOOL>_`.
+func (l *ConfigLoader[T]) EnvPrefix() string {
+	return "SFTOOL_" + strings.ToUpper(l.Tool) + "_"
+}
+
+// FileEnv returns the name of the environment variable overriding the config file location.
+func (l *ConfigLoader[T]) FileEnv() string {
+	return l.EnvPrefix() + "CONFIG"
+}
+
+// DefaultFile returns the default config file of the tool,
+// `$HOME/.config/streamingfast/tooling/<tool>.yaml`.
+func (l *ConfigLoader[T]) DefaultFile() (string, error) {
+	directory, err := toolingConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(directory, l.Tool+".yaml"), nil
+}
+
+// ResolveFile returns the config file to read given `overrides`: the `--config` flag value,
+// then the `SFTOOL_<TOOL>_CONFIG` environment variable, then the default file if it exists,
+// then the first existing legacy file and finally the default file even if it does not exist.
+func (l *ConfigLoader[T]) ResolveFile(overrides ConfigOverrides) (string, error) {
+	if overrides.File != "" {
+		return overrides.File, nil
+	}
+
+	if file := lookupConfigEnv(l.FileEnv()); file != "" {
+		return file, nil
+	}
+
+	defaultFile, err := l.DefaultFile()
+	if err != nil {
+		return "", err
+	}
+
+	if fileExists(defaultFile) {
+		return defaultFile, nil
+	}
+
+	if userHome, err := os.UserHomeDir(); err == nil {
+		for _, legacyFile := range l.LegacyFiles {
+			if candidate := filepath.Join(userHome, legacyFile); fileExists(candidate) {
+				return candidate, nil
+			}
+		}
+	}
+
+	return defaultFile, nil
+}
+
+// Load reads the config from all its sources and validates it.
+func (l *ConfigLoader[T]) Load(overrides ConfigOverrides) (*LoadedConfig[T], error) {
+	loaded := &LoadedConfig[T]{Config: new(T), Origins: map[string]string{}}
+	if l.Default != nil {
+		loaded.Config = l.Default()
+	}
+
+	var err error
+	if loaded.File, err = l.ResolveFile(overrides); err != nil {
+		return nil, fmt.Errorf("resolve config file: %w", err)
+	}
+
+	content, err := os.ReadFile(loaded.File)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && overrides.File == "":
+		// No config file, defaults are used
+	case err != nil:
+		return nil, fmt.Errorf("unable to read config file %q: %w", loaded.File, err)
+	default:
+		loaded.FileExists = true
+		if err := decodeConfig(content, loaded.Config); err != nil {
+			return nil, fmt.Errorf("invalid config file %q: %w", loaded.File, err)
+		}
+	}
+
+	if err := l.applyEnv(loaded); err != nil {
+		return nil, err
+	}
+
+	for _, set := range overrides.Sets {
+		key, value, found := strings.Cut(set, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid --set %q, expecting <key>=<value>", set)
+		}
+
+		if err := SetConfigValue(loaded.Config, strings.TrimSpace(key), value); err != nil {
+			return nil, fmt.Errorf("invalid --set %q: %w", set, err)
+		}
+
+		loaded.Origins[strings.TrimSpace(key)] = "--set"
+	}
+
+	if l.Validate != nil {
+		if err := l.Validate(loaded.Config); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	return loaded, nil
+}
+
+func (l *ConfigLoader[T]) applyEnv(loaded *LoadedConfig[T]) error {
+	prefix := l.EnvPrefix()
+
+	keysByEnv := map[string]string{}
+	for _, key := range ConfigKeys(loaded.Config) {
+		keysByEnv[prefix+strings.ToUpper(strings.ReplaceAll(key, ".", "_"))] = key
+	}
+
+	var envs []string
+	for _, entry := range configEnviron() {
+		if name, _, _ := strings.Cut(entry, "="); strings.HasPrefix(name, prefix) && name != l.FileEnv() {
+			envs = append(envs, name)
+		}
+	}
+	sort.Strings(envs)
+
+	for _, env := range envs {
+		key, found := keysByEnv[env]
+		if !found {
+			return fmt.Errorf("unknown config environment variable %s%s", env, suggestion(env, mapKeys(keysByEnv)))
+		}
+
+		if err := SetConfigValue(loaded.Config, key, lookupConfigEnv(env)); err != nil {
+			return fmt.Errorf("invalid environment variable %s: %w", env, err)
+		}
+
+		loaded.Origins[key] = env
+	}
+
+	return nil
+}
+
+// decodeConfig strictly decodes the YAML `content` into `config`, reporting every unknown key
+func decodeConfig(content []byte, config any) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return err
+	}
+
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	if err := checkConfigKeys(document.Content[0], reflect.TypeOf(config), ""); err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+func checkConfigKeys(node *yaml.Node, typ reflect.Type, path string) error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	var errs []error
+	switch {
+	case typ.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := configFields(typ)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			field, found := fields[key.Value]
+			if !found {
+				errs = append(errs, fmt.Errorf("line %d: unknown key %q%s", key.Line, joinConfigKey(path, key.Value), suggestion(key.Value, mapKeys(fields))))
+				continue
+			}
+
+			errs = append(errs, checkConfigKeys(value, field.Type, joinConfigKey(path, key.Value)))
+		}
+
+	case typ.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			errs = append(errs, checkConfigKeys(node.Content[i+1], typ.Elem(), joinConfigKey(path, node.Content[i].Value)))
+		}
+
+	case typ.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, element := range node.Content {
+			errs = append(errs, checkConfigKeys(element, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// configFields returns the fields of struct `typ` keyed by their YAML name
+func configFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
+
+// ConfigKeys returns the dotted paths of the scalar keys of `config` reachable through structs,
+// which are the keys that can be set from the environment.
+func ConfigKeys(config any) (out []string) {
+	var collect func(typ reflect.Type, path string)
+	collect = func(typ reflect.Type, path string) {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			for name, field := range configFields(typ) {
+				collect(field.Type, joinConfigKey(path, name))
+			}
+
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+			// Dynamic keys cannot be listed
+
+		default:
+			out = append(out, path)
+		}
+	}
+
+	collect(reflect.TypeOf(config), "")
+	sort.Strings(out)
+
+	return out
+}
+
+// SetConfigValue sets the key at dotted `path` of `config` (a pointer) to `value`. String keys
+// are set verbatim while other keys are decoded from `value` as YAML, so `true`, `10` or `[a, b]`
+// can be used. Path elements going through a map are map keys, created when missing.
+func SetConfigValue(config any, path string, value string) error {
+	if path == "" {
+		return fmt.Errorf("empty key")
+	}
+
+	return setConfigValue(reflect.ValueOf(config), strings.Split(path, "."), path, value)
+}
+
+func setConfigValue(target reflect.Value, segments []string, path string, value string) error {
+	for target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		target = target.Elem()
+	}
+
+	if len(segments) == 0 {
+		if target.Kind() == reflect.String {
+			target.SetString(value)
+			return nil
+		}
+
+		if err := yaml.Unmarshal([]byte(value), target.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid value %q for key %q: %w", value, path, err)
+		}
+
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		fields := configFields(target.Type())
+
+		field, found := fields[segments[0]]
+		if !found {
+			return fmt.Errorf("unknown key %q%s", path, suggestion(segments[0], mapKeys(fields)))
+		}
+
+		return setConfigValue(target.FieldByIndex(field.Index), segments[1:], path, value)
+
+	case reflect.Map:
+		if target.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+
+		key := reflect.ValueOf(segments[0]).Convert(target.Type().Key())
+
+		element := reflect.New(target.Type().Elem()).Elem()
+		if existing := target.MapIndex(key); existing.IsValid() {
+			element.Set(existing)
+		}
+
+		if err := setConfigValue(element, segments[1:], path, value); err != nil {
+			return err
+		}
+
+		target.SetMapIndex(key, element)
+		return nil
+	}
+
+	return fmt.Errorf("unknown key %q, %q has no sub keys", path, strings.TrimSuffix(path, "."+strings.Join(segments, ".")))
+}
+
+func joinConfigKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func lookupConfigEnv(name string) string {
+	for _, entry := range configEnviron() {
+		if key, value, _ := strings.Cut(entry, "="); key == name {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func fileExists(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && !stat.IsDir()
+}
+
+func mapKeys[V any](in map[string]V) []string {
+	out := make([]string, 0, len(in))
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// suggestion returns `, did you mean "<candidate>"?` for the candidate closest to `in` or the
+// list of valid candidates if none is close enough
+func suggestion(in string, candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToLower(in), strings.ToLower(candidate))
+		if bestDistance == -1 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	if bestDistance <= max(2, len(in)/4) {
+		return fmt.Sprintf(", did you mean %q?", best)
+	}
+
+	return fmt.Sprintf(", valid keys are %s", strings.Join(candidates, ", "))
+}
+
+func levenshtein(left, right string) int {
+	previous := make([]int, len(right)+1)
+	current := make([]int, len(right)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(left); i++ {
+		current[0] = i
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(right)]
+}
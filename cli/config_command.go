@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	sfcli "github.com/streamingfast/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// RegisterConfigFlags registers the `--config` and `--set` flags read by [ConfigOverridesFromCommand].
+func RegisterConfigFlags(flags *pflag.FlagSet) {
+	flags.String("config", "", "Config file to use instead of the default one, see 'config path'")
+	flags.StringArray("set", nil, "Override a config key, in the form <key>=<value> (e.g. 'default_work_dir=~/work'), can be repeated")
+}
+
+// ConfigOverridesFromCommand returns the config overrides from the flags registered by
+// [RegisterConfigFlags], flags not registered on `cmd` are ignored.
+func ConfigOverridesFromCommand(cmd *cobra.Command) (out ConfigOverrides) {
+	out.File, _ = cmd.Flags().GetString("config")
+	out.Sets, _ = cmd.Flags().GetStringArray("set")
+
+	return out
+}
+
+// LoadFromCommand is [ConfigLoader.Load] using the overrides of [ConfigOverridesFromCommand].
+func (l *ConfigLoader[T]) LoadFromCommand(cmd *cobra.Command) (*LoadedConfig[T], error) {
+	return l.Load(ConfigOverridesFromCommand(cmd))
+}
+
+// Group returns the `config show|validate|path` commands group and registers, as persistent
+// flags of the command it's added to, the flags of [RegisterConfigFlags].
+func (l *ConfigLoader[T]) Group() sfcli.CommandOption {
+	legacyFiles := make([]string, len(l.LegacyFiles))
+	for i, legacyFile := range l.LegacyFiles {
+		legacyFiles[i] = "~/" + legacyFile
+	}
+
+	return sfcli.CommandOptionFunc(func(parent *cobra.Command) {
+		RegisterConfigFlags(parent.PersistentFlags())
+
+		sfcli.Group(
+			"config",
+			fmt.Sprintf("Inspect the configuration of %s", l.Tool),
+			sfcli.Description(fmt.Sprintf(`
+				The configuration is read, from lowest to highest precedence, from the defaults, the
+				config file, the environment variables %s<KEY> and the '--set <key>=<value>' flags.
+
+				The config file is the first of '--config', $%s, the default file, which is
+				'%s.yaml' in the tooling config directory, or the legacy file(s) [%s] if they exist.
+			`, l.EnvPrefix(), l.FileEnv(), l.Tool, strings.Join(legacyFiles, ", "))),
+
+			sfcli.Command(l.show,
+				"show",
+				"Prints the effective configuration, sources merged, as YAML",
+				sfcli.NoArgs(),
+			),
+			sfcli.Command(l.validate,
+				"validate",
+				"Validates the configuration, reporting unknown keys and invalid values",
+				sfcli.NoArgs(),
+			),
+			sfcli.Command(l.path,
+				"path",
+				"Prints the path of the config file in use, even if it does not exist",
+				sfcli.NoArgs(),
+			),
+		).Apply(parent)
+	})
+}
+
+func (l *ConfigLoader[T]) show(cmd *cobra.Command, _ []string) error {
+	loaded, err := l.LoadFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	var document yaml.Node
+	if err := document.Encode(loaded.Config); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	annotateConfigOrigins(&document, "", loaded.Origins)
+
+	document.HeadComment = fmt.Sprintf("Config file %s", loaded.File)
+	if !loaded.FileExists {
+		document.HeadComment += " (not found, using defaults)"
+	}
+
+	out, err := yaml.Marshal(&document)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	fmt.Print(string(out))
+	return nil
+}
+
+func (l *ConfigLoader[T]) validate(cmd *cobra.Command, _ []string) error {
+	loaded, err := l.LoadFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !loaded.FileExists {
+		fmt.Printf("Config file %s not found, configuration from defaults and environment is valid\n", loaded.File)
+		return nil
+	}
+
+	fmt.Printf("Config file %s is valid\n", loaded.File)
+	return nil
+}
+
+func (l *ConfigLoader[T]) path(cmd *cobra.Command, _ []string) error {
+	file, err := l.ResolveFile(ConfigOverridesFromCommand(cmd))
+	if err != nil {
+		return err
+	}
+
+	if !fileExists(file) {
+		fmt.Fprintf(os.Stderr, "Config file %s does not exist, defaults are used\n", file)
+	}
+
+	fmt.Println(file)
+	return nil
+}
+
+// annotateConfigOrigins adds a line comment to the values of `node` that were set by a source
+// other than the defaults or the config file
+func annotateConfigOrigins(node *yaml.Node, path string, origins map[string]string) {
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			annotateConfigOrigins(child, path, origins)
+		}
+
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := joinConfigKey(path, node.Content[i].Value)
+		if origin, found := origins[key]; found {
+			node.Content[i+1].LineComment = "from " + origin
+		}
+
+		annotateConfigOrigins(node.Content[i+1], key, origins)
+	}
+}
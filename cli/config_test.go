@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	WorkDir   string                     `yaml:"work_dir"`
+	Shortcut  string                     `yaml:"shortcut"`
+	AfterBump *testAfterBump             `yaml:"after_bump"`
+	Clusters  map[string]*testClusterDef `yaml:"clusters"`
+	Aliases   []string                   `yaml:"aliases"`
+}
+
+type testAfterBump struct {
+	GoModTidy bool `yaml:"go_mod_tidy"`
+}
+
+type testClusterDef struct {
+	User string `yaml:"user"`
+}
+
+func TestConfigLoader_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		env         []string
+		sets        []string
+		want        *testConfig
+		wantOrigins map[string]string
+		wantErr     string
+	}{
+		{
+			name: "defaults only",
+			want: &testConfig{Shortcut: "github.com/"},
+		},
+		{
+			name:    "file",
+			content: "work_dir: /work\nafter_bump:\n  go_mod_tidy: true\nclusters:\n  dev:\n    user: me\n",
+			want: &testConfig{WorkDir: "/work", Shortcut: "github.com/", AfterBump: &testAfterBump{GoModTidy: true}, Clusters: map[string]*testClusterDef{
+				"dev": {User: "me"},
+			}},
+		},
+		{
+			name:        "env over file",
+			content:     "work_dir: /work\n",
+			env:         []string{"SFTOOL_TEST_WORK_DIR=/env", "SFTOOL_TEST_AFTER_BUMP_GO_MOD_TIDY=true"},
+			want:        &testConfig{WorkDir: "/env", Shortcut: "github.com/", AfterBump: &testAfterBump{GoModTidy: true}},
+			wantOrigins: map[string]string{"work_dir": "SFTOOL_TEST_WORK_DIR", "after_bump.go_mod_tidy": "SFTOOL_TEST_AFTER_BUMP_GO_MOD_TIDY"},
+		},
+		{
+			name:        "set over env",
+			env:         []string{"SFTOOL_TEST_WORK_DIR=/env"},
+			sets:        []string{"work_dir=/flag", "clusters.prod.user=@ops", "aliases=[a, b]"},
+			want:        &testConfig{WorkDir: "/flag", Shortcut: "github.com/", Clusters: map[string]*testClusterDef{"prod": {User: "@ops"}}, Aliases: []string{"a", "b"}},
+			wantOrigins: map[string]string{"work_dir": "--set", "clusters.prod.user": "--set", "aliases": "--set"},
+		},
+		{
+			name:    "unknown key suggests closest",
+			content: "work_dir: /work\nshortcutt: github.com/\n",
+			wantErr: `line 2: unknown key "shortcutt", did you mean "shortcut"?`,
+		},
+		{
+			name:    "unknown nested key",
+			content: "clusters:\n  dev:\n    usr: me\n",
+			wantErr: `line 3: unknown key "clusters.dev.usr", did you mean "user"?`,
+		},
+		{
+			name:    "unknown key lists valid ones",
+			content: "completely_off: true\n",
+			wantErr: `unknown key "completely_off", valid keys are after_bump, aliases, clusters, shortcut, work_dir`,
+		},
+		{
+			name:    "invalid type",
+			content: "after_bump:\n  go_mod_tidy: maybe\n",
+			wantErr: "cannot unmarshal !!str `maybe` into bool",
+		},
+		{
+			name:    "unknown env",
+			env:     []string{"SFTOOL_TEST_WORKDIR=/env"},
+			wantErr: "unknown config environment variable SFTOOL_TEST_WORKDIR, did you mean \"SFTOOL_TEST_WORK_DIR\"?",
+		},
+		{
+			name:    "unknown set",
+			sets:    []string{"after_bump.tidy=true"},
+			wantErr: `unknown key "after_bump.tidy", valid keys are go_mod_tidy`,
+		},
+		{
+			name:    "set on scalar sub key",
+			sets:    []string{"work_dir.sub=true"},
+			wantErr: `unknown key "work_dir.sub", "work_dir" has no sub keys`,
+		},
+		{
+			name:    "validation",
+			sets:    []string{"shortcut=https://github.com"},
+			wantErr: "invalid config: shortcut must not be an URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "test.yaml")
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(file, []byte(tt.content), 0644))
+			}
+
+			configEnviron = func() []string {
+				return append([]string{"SFTOOL_TEST_CONFIG=" + file, "SFTOOL_OTHER_KEY=1"}, tt.env...)
+			}
+			defer func() { configEnviron = os.Environ }()
+
+			loader := &ConfigLoader[testConfig]{
+				Tool:    "test",
+				Default: func() *testConfig { return &testConfig{Shortcut: "github.com/"} },
+				Validate: func(config *testConfig) error {
+					if config.Shortcut == "https://github.com" {
+						return fmt.Errorf("shortcut must not be an URL")
+					}
+					return nil
+				},
+			}
+
+			loaded, err := loader.Load(ConfigOverrides{Sets: tt.sets})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, file, loaded.File)
+			assert.Equal(t, tt.content != "", loaded.FileExists)
+			assert.Equal(t, tt.want, loaded.Config)
+
+			if tt.wantOrigins == nil {
+				tt.wantOrigins = map[string]string{}
+			}
+			assert.Equal(t, tt.wantOrigins, loaded.Origins)
+		})
+	}
+}
+
+func TestConfigLoader_ResolveFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configEnviron = func() []string { return nil }
+	defer func() { configEnviron = os.Environ }()
+
+	loader := &ConfigLoader[testConfig]{Tool: "test", LegacyFiles: []string{".config/test/default.yaml"}}
+	defaultFile := filepath.Join(home, ".config", "streamingfast", "tooling", "test.yaml")
+	legacyFile := filepath.Join(home, ".config", "test", "default.yaml")
+
+	resolve := func(overrides ConfigOverrides) string {
+		file, err := loader.ResolveFile(overrides)
+		require.NoError(t, err)
+
+		return file
+	}
+
+	assert.Equal(t, defaultFile, resolve(ConfigOverrides{}))
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacyFile), 0755))
+	require.NoError(t, os.WriteFile(legacyFile, nil, 0644))
+	assert.Equal(t, legacyFile, resolve(ConfigOverrides{}))
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(defaultFile), 0755))
+	require.NoError(t, os.WriteFile(defaultFile, nil, 0644))
+	assert.Equal(t, defaultFile, resolve(ConfigOverrides{}))
+
+	configEnviron = func() []string { return []string{"SFTOOL_TEST_CONFIG=/from/env.yaml"} }
+	assert.Equal(t, "/from/env.yaml", resolve(ConfigOverrides{}))
+	assert.Equal(t, "/from/flag.yaml", resolve(ConfigOverrides{File: "/from/flag.yaml"}))
+}
+
+func TestConfigKeys(t *testing.T) {
+	assert.Equal(t, []string{"after_bump.go_mod_tidy", "shortcut", "work_dir"}, ConfigKeys(&testConfig{}))
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// AssumeYesEnv is the environment variable that, when set to a true value (`1`, `true`, ...),
+// answers yes to all confirmations, like the `-yes` flag of [RegisterConfirmFlags].
+const AssumeYesEnv = "SFTOOL_ASSUME_YES"
+
+// ErrConfirmationUnavailable is returned by [Confirm] when the user cannot be asked because
+// standard input is not a terminal and no answer was assumed.
+var ErrConfirmationUnavailable = errors.New("confirmation required but standard input is not a terminal, use -yes or " + AssumeYesEnv + "=true to assume yes")
+
+// confirmInput, confirmOutput and confirmIsTerminal are overridden in tests to simulate the user.
+// The input is shared by all confirmations so that buffered answers are not lost between them.
+var confirmInput = sync.OnceValue(func() *confirmReader { return newConfirmReader(openConfirmSource()) })
+var confirmOutput io.Writer = os.Stderr
+var confirmIsTerminal = stdinIsTerminal
+
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// openConfirmSource returns the terminal to read answers from. The terminal is opened again
+// as `/dev/tty` because, contrary to [os.Stdin], such file supports read deadlines which
+// bound the prompt without a goroutine blocked in a read, see [WithConfirmTimeout].
+func openConfirmSource() io.Reader {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return os.Stdin
+	}
+
+	return tty
+}
+
+// confirmReader reads the answers line by line from its source, which can bound the reads
+// if it supports read deadlines
+type confirmReader struct {
+	source io.Reader
+	lines  *bufio.Reader
+}
+
+func newConfirmReader(source io.Reader) *confirmReader {
+	return &confirmReader{source: source, lines: bufio.NewReader(source)}
+}
+
+var errConfirmTimeout = errors.New("confirmation timed out")
+
+// readLine returns the next answer, trimmed, failing with errConfirmTimeout if none is
+// read before `deadline` when it is not zero
+func (r *confirmReader) readLine(deadline time.Time) (string, error) {
+	if !deadline.IsZero() {
+		source, ok := r.source.(interface{ SetReadDeadline(time.Time) error })
+		if !ok {
+			return "", fmt.Errorf("confirmation input does not support timeouts")
+		}
+
+		if err := source.SetReadDeadline(deadline); err != nil {
+			return "", fmt.Errorf("unable to bound confirmation: %w", err)
+		}
+		defer source.SetReadDeadline(time.Time{})
+	}
+
+	line, err := r.lines.ReadString('\n')
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return "", errConfirmTimeout
+	}
+
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+var assumeYesFlag bool
+var confirmTimeoutFlag time.Duration
+
+// RegisterConfirmFlags registers the shared `-yes` and `-confirm-timeout` flags on the received
+// flag set, see [Confirm].
+func RegisterConfirmFlags(flags FlagSet) {
+	flags.BoolVar(&assumeYesFlag, "yes", false, fmt.Sprintf("Answer yes to all confirmations, also enabled by %s=true", AssumeYesEnv))
+	flags.DurationVar(&confirmTimeoutFlag, "confirm-timeout", 0, "Time given to answer a confirmation before its default answer is used, or the command aborted if it has none, 0 means no limit")
+}
+
+// AssumeYes returns true if confirmations are answered yes by the `-yes` flag or the
+// [AssumeYesEnv] environment variable.
+func AssumeYes() bool {
+	if assumeYesFlag {
+		return true
+	}
+
+	value, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(AssumeYesEnv)))
+	return err == nil && value
+}
+
+type confirmOptions struct {
+	defaultAnswer *bool
+	timeout       time.Duration
+	typedName     string
+}
+
+type ConfirmOption func(o *confirmOptions)
+
+// WithConfirmDefault sets the answer used when the user just presses enter, when the prompt times
+// out and, for confirmations not requiring a typed name, when standard input is not a terminal.
+func WithConfirmDefault(answer bool) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.defaultAnswer = &answer
+	}
+}
+
+// WithConfirmTimeout bounds the time the user has to answer, the default answer is used when it
+// expires or an error is returned if there is none. It overrides the `-confirm-timeout` flag of
+// [RegisterConfirmFlags].
+func WithConfirmTimeout(timeout time.Duration) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithConfirmTypedName requires the user to type `name` exactly, like a bucket name, instead
+// of answering yes, meant for destructive actions. Such confirmation is never answered by a
+// default, only by the user or by [AssumeYes].
+func WithConfirmTypedName(name string) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.typedName = name
+	}
+}
+
+// Confirm asks the user to confirm `message` on standard error, reading the answer from standard
+// input. It returns true without asking if [AssumeYes] is true. When standard input is not a
+// terminal, the default answer is used if any (and no typed name is required), otherwise
+// [ErrConfirmationUnavailable] is returned. The time given to answer is bounded by the
+// `-confirm-timeout` flag or [WithConfirmTimeout].
+func Confirm(message string, opts ...ConfirmOption) (bool, error) {
+	options := confirmOptions{timeout: confirmTimeoutFlag}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if AssumeYes() {
+		fmt.Fprintf(confirmOutput, "%s (yes assumed)\n", message)
+		return true, nil
+	}
+
+	if !confirmIsTerminal() {
+		if options.defaultAnswer != nil && options.typedName == "" {
+			return *options.defaultAnswer, nil
+		}
+
+		return false, ErrConfirmationUnavailable
+	}
+
+	var deadline time.Time
+	if options.timeout > 0 {
+		deadline = time.Now().Add(options.timeout)
+	}
+
+	for {
+		fmt.Fprint(confirmOutput, confirmPrompt(message, options))
+
+		line, err := confirmInput().readLine(deadline)
+		if errors.Is(err, errConfirmTimeout) {
+			fmt.Fprintln(confirmOutput)
+			if options.defaultAnswer != nil && options.typedName == "" {
+				fmt.Fprintf(confirmOutput, "No answer after %s, using the default answer\n", options.timeout)
+				return *options.defaultAnswer, nil
+			}
+
+			return false, fmt.Errorf("no answer after %s", options.timeout)
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("unable to read confirmation: %w", err)
+		}
+
+		if options.typedName != "" {
+			return line == options.typedName, nil
+		}
+
+		if line == "" && options.defaultAnswer != nil {
+			return *options.defaultAnswer, nil
+		}
+
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+
+		fmt.Fprintln(confirmOutput, "Only yes or no accepted, please retry!")
+	}
+}
+
+func confirmPrompt(message string, options confirmOptions) string {
+	if options.typedName != "" {
+		return fmt.Sprintf("%s\nType %q to confirm: ", message, options.typedName)
+	}
+
+	choices := "y/n"
+	if options.defaultAnswer != nil {
+		choices = map[bool]string{true: "Y/n", false: "y/N"}[*options.defaultAnswer]
+	}
+
+	return fmt.Sprintf("%s [%s] ", message, choices)
+}
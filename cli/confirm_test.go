@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name       string
+		terminal   bool
+		assumeYes  string
+		input      string
+		opts       []ConfirmOption
+		want       bool
+		wantPrompt string
+		wantErr    string
+	}{
+		{"yes", true, "", "y\n", nil, true, "Delete? [y/n] ", ""},
+		{"no", true, "", "No\n", nil, false, "Delete? [y/n] ", ""},
+		{"retry until valid", true, "", "maybe\nyes\n", nil, true, "Delete? [y/n] Only yes or no accepted, please retry!\nDelete? [y/n] ", ""},
+		{"empty uses default", true, "", "\n", []ConfirmOption{WithConfirmDefault(true)}, true, "Delete? [Y/n] ", ""},
+		{"empty without default retries", true, "", "\nn\n", nil, false, "Delete? [y/n] Only yes or no accepted, please retry!\nDelete? [y/n] ", ""},
+		{"typed name matches", true, "", "my-bucket\n", []ConfirmOption{WithConfirmTypedName("my-bucket")}, true, "Delete?\nType \"my-bucket\" to confirm: ", ""},
+		{"typed name mismatches", true, "", "yes\n", []ConfirmOption{WithConfirmTypedName("my-bucket")}, false, "", ""},
+		{"input closed", true, "", "", nil, false, "", "unable to read confirmation: EOF"},
+		{"assume yes env", false, "true", "", []ConfirmOption{WithConfirmTypedName("my-bucket")}, true, "Delete? (yes assumed)\n", ""},
+		{"assume yes env false", false, "0", "", nil, false, "", ErrConfirmationUnavailable.Error()},
+		{"not a terminal uses default", false, "", "", []ConfirmOption{WithConfirmDefault(false)}, false, "", ""},
+		{"not a terminal typed name", false, "", "", []ConfirmOption{WithConfirmDefault(true), WithConfirmTypedName("my-bucket")}, false, "", ErrConfirmationUnavailable.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := mockConfirm(t, tt.terminal, strings.NewReader(tt.input))
+			t.Setenv(AssumeYesEnv, tt.assumeYes)
+
+			got, err := Confirm("Delete?", tt.opts...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.wantPrompt != "" {
+				assert.Equal(t, tt.wantPrompt, output.String())
+			}
+		})
+	}
+}
+
+func TestConfirm_Timeout(t *testing.T) {
+	// A pipe, like a terminal, supports read deadlines
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	defer writer.Close()
+
+	output := mockConfirm(t, true, reader)
+
+	got, err := Confirm("Delete?", WithConfirmDefault(false), WithConfirmTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+	assert.False(t, got)
+	assert.Equal(t, "Delete? [y/N] \nNo answer after 10ms, using the default answer\n", output.String())
+
+	_, err = Confirm("Delete?", WithConfirmTimeout(10*time.Millisecond))
+	assert.EqualError(t, err, "no answer after 10ms")
+
+	// The reader is still usable once a prompt timed out
+	_, err = writer.WriteString("yes\n")
+	require.NoError(t, err)
+
+	got, err = Confirm("Delete?", WithConfirmTimeout(time.Second))
+	require.NoError(t, err)
+	assert.True(t, got)
+}
+
+func TestConfirm_TimeoutUnsupported(t *testing.T) {
+	mockConfirm(t, true, strings.NewReader("yes\n"))
+
+	_, err := Confirm("Delete?", WithConfirmTimeout(time.Second))
+	assert.EqualError(t, err, "unable to read confirmation: confirmation input does not support timeouts")
+}
+
+func mockConfirm(t *testing.T, terminal bool, input io.Reader) *bytes.Buffer {
+	output := bytes.NewBuffer(nil)
+
+	previousInput := confirmInput
+	reader := newConfirmReader(input)
+	confirmInput, confirmOutput, confirmIsTerminal = func() *confirmReader { return reader }, output, func() bool { return terminal }
+	t.Cleanup(func() {
+		confirmInput, confirmOutput, confirmIsTerminal = previousInput, os.Stderr, stdinIsTerminal
+	})
+
+	return output
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateMatch is a date found inside a line by [ExtractDateLikeInput], the date
+// text is `line[Start:End]`.
+type DateMatch struct {
+	Time  time.Time
+	Start int
+	End   int
+	Trace *DateParseTrace
+}
+
+var dateExtractionFlag bool
+var dateExtractionNthFlag int
+
+// RegisterDateExtractionFlags registers the shared `-extract` and `-extract-nth` flags on the
+// received flag set, see [DateExtractionEnabled] and [ExtractDateLikeInputFromFlags].
+func RegisterDateExtractionFlags(flags FlagSet) {
+	flags.BoolVar(&dateExtractionFlag, "extract", false, "Extract the date found anywhere in each element (e.g. a log line) instead of expecting the whole element to be a date")
+	flags.IntVar(&dateExtractionNthFlag, "extract-nth", 1, "When -extract is used, use the Nth (1-based) date found in the element instead of the first one")
+}
+
+func DateExtractionEnabled() bool {
+	return dateExtractionFlag
+}
+
+// ExtractDateLikeInputFromFlags is [ExtractDateLikeInput] using the `-extract-nth` flag value.
+func ExtractDateLikeInputFromFlags(line string, timezoneIfUnset *time.Location) (match DateMatch, err error) {
+	if dateExtractionNthFlag < 1 {
+		return match, fmt.Errorf("invalid -extract-nth value %d, must be 1 or higher", dateExtractionNthFlag)
+	}
+
+	match, ok := ExtractDateLikeInput(line, dateExtractionNthFlag, timezoneIfUnset)
+	if !ok {
+		if dateExtractionNthFlag == 1 {
+			return match, fmt.Errorf("no date found")
+		}
+
+		return match, fmt.Errorf("no date #%d found", dateExtractionNthFlag)
+	}
+
+	return match, nil
+}
+
+// ExtractDateLikeInput scans `line` for date-like substrings using the layouts of
+// [DefaultDateLayouts] and returns the `nth` (1-based) one. Dates are ordered by
+// position, when multiple layouts match at the same position, the longest match
+// is used and the next date is searched after it.
+func ExtractDateLikeInput(line string, nth int, timezoneIfUnset *time.Location) (match DateMatch, ok bool) {
+	matches := ExtractAllDateLikeInputs(line, timezoneIfUnset)
+	if nth < 1 || nth > len(matches) {
+		return match, false
+	}
+
+	return matches[nth-1], true
+}
+
+// ExtractAllDateLikeInputs returns all the non-overlapping dates found in `line`, see [ExtractDateLikeInput].
+//
+// The line is scanned once with the alternation of all layouts (see [DateLayoutRegistry.extractionRegexp])
+// to jump over the text not looking like a date. At each position where it matches, the layouts are tried
+// anchored there and the longest one parsing successfully wins.
+func ExtractAllDateLikeInputs(line string, timezoneIfUnset *time.Location) (out []DateMatch) {
+	registry := DefaultDateLayouts()
+	layouts := append(append([]DateLayout{}, registry.Zoned()...), registry.Local()...)
+	expression := registry.extractionRegexp()
+
+	for offset := 0; offset < len(line); {
+		location := expression.FindStringIndex(line[offset:])
+		if location == nil {
+			break
+		}
+
+		start := offset + location[0]
+		if match, ok := extractDateAt(line, start, layouts, timezoneIfUnset); ok {
+			out = append(out, match)
+			offset = match.End
+			continue
+		}
+
+		offset = start + 1
+	}
+
+	return out
+}
+
+// extractDateAt returns the longest date starting at `start` in `line` that parses with one of `layouts`
+func extractDateAt(line string, start int, layouts []DateLayout, timezoneIfUnset *time.Location) (match DateMatch, ok bool) {
+	if isDigitAt(line, start-1) {
+		return match, false
+	}
+
+	var ends []int
+	for _, layout := range layouts {
+		location := layoutRegexp(layout.Layout).FindStringIndex(line[start:])
+		if location != nil && !isDigitAt(line, start+location[1]) {
+			ends = append(ends, start+location[1])
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(ends)))
+
+	for _, end := range ends {
+		parsed, trace, ok := ExplainDateLikeInput(line[start:end], DateLikeHintNone, timezoneIfUnset)
+		if ok {
+			return DateMatch{Time: parsed, Start: start, End: end, Trace: trace}, true
+		}
+	}
+
+	return match, false
+}
+
+func isDigitAt(in string, index int) bool {
+	return index >= 0 && index < len(in) && in[index] >= '0' && in[index] <= '9'
+}
+
+var layoutRegexps sync.Map
+
+// layoutRegexp returns a regular expression matching, at the start of the input only, the
+// text a Go reference layout parses
+func layoutRegexp(layout string) *regexp.Regexp {
+	if cached, found := layoutRegexps.Load(layout); found {
+		return cached.(*regexp.Regexp)
+	}
+
+	expression := regexp.MustCompile(`^(?:` + layoutToRegexp(layout) + `)`)
+	layoutRegexps.Store(layout, expression)
+
+	return expression
+}
+
+// layoutTokens are the Go reference layout elements, longest first when sharing a prefix,
+// with the regular expression matching what they parse.
+var layoutTokens = []struct {
+	token      string
+	expression string
+}{
+	{"January", `[A-Z][a-z]{2,8}`},
+	{"Jan", `[A-Z][a-z]{2}`},
+	{"Monday", `[A-Z][a-z]{5,8}`},
+	{"Mon", `[A-Z][a-z]{2}`},
+	{"MST", `(?:[A-Z]{3,5}|GMT[+-]\d{1,2})`},
+	{"2006", `\d{4}`},
+	{"002", `\d{3}`},
+	{"__2", `[ \d]{2}\d`},
+	{"_2", `[ \d]\d`},
+	{"01", `\d{2}`},
+	{"02", `\d{2}`},
+	{"03", `\d{2}`},
+	{"04", `\d{2}`},
+	// Go accepts a fractional second after the seconds even if the layout has none
+	{"05", `\d{2}(?:[.,]\d+)?`},
+	{"06", `\d{2}`},
+	{"15", `\d{2}`},
+	{"1", `\d{1,2}`},
+	{"2", `\d{1,2}`},
+	{"3", `\d{1,2}`},
+	{"4", `\d{1,2}`},
+	{"5", `\d{1,2}(?:[.,]\d+)?`},
+	{"PM", `[AP]M`},
+	{"pm", `[ap]m`},
+	{"Z07:00:00", `(?:Z|[+-]\d{2}:\d{2}:\d{2})`},
+	{"Z070000", `(?:Z|[+-]\d{6})`},
+	{"Z07:00", `(?:Z|[+-]\d{2}:\d{2})`},
+	{"Z0700", `(?:Z|[+-]\d{4})`},
+	{"Z07", `(?:Z|[+-]\d{2})`},
+	{"-07:00:00", `[+-]\d{2}:\d{2}:\d{2}`},
+	{"-070000", `[+-]\d{6}`},
+	{"-07:00", `[+-]\d{2}:\d{2}`},
+	{"-0700", `[+-]\d{4}`},
+	{"-07", `[+-]\d{2}`},
+}
+
+var layoutFractionRegexp = regexp.MustCompile(`^[.,](0+|9+)`)
+
+func layoutToRegexp(layout string) string {
+	builder := strings.Builder{}
+
+	for i := 0; i < len(layout); {
+		// Fractional seconds like `.000` (fixed digits) or `.999` (optional trailing digits)
+		if match := layoutFractionRegexp.FindStringSubmatch(layout[i:]); match != nil && !isDigitAt(layout, i+len(match[0])) {
+			digits := len(match[1])
+			if match[1][0] == '0' {
+				builder.WriteString(fmt.Sprintf(`[.,]\d{%d}`, digits))
+			} else {
+				builder.WriteString(fmt.Sprintf(`(?:[.,]\d{1,%d})?`, digits))
+			}
+
+			i += len(match[0])
+			continue
+		}
+
+		matched := false
+		for _, candidate := range layoutTokens {
+			if strings.HasPrefix(layout[i:], candidate.token) {
+				builder.WriteString(candidate.expression)
+				i += len(candidate.token)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			builder.WriteString(regexp.QuoteMeta(layout[i : i+1]))
+			i++
+		}
+	}
+
+	return builder.String()
+}
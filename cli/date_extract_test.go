@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractDateLikeInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		nth      int
+		wantText string
+		wantDate string
+	}{
+		{
+			"zap-pretty",
+			"[2024-07-23 14:37:10.304 EDT] INFO (merger) merged bundle {\"base_block\": 1000}",
+			1,
+			"2024-07-23 14:37:10.304 EDT",
+			"2024-07-23 14:37:10.304-04:00",
+		},
+		{
+			"polygon",
+			"INFO [11-29|08:28:27.718] Imported new chain segment number=64,719,230",
+			1,
+			"11-29|08:28:27.718",
+			"2024-11-29 08:28:27.718-05:00",
+		},
+		{
+			"rfc3339 in json",
+			`{"level":"info","ts":"2023-04-13T14:25:27.180-04:00","msg":"ready"}`,
+			1,
+			"2023-04-13T14:25:27.180-04:00",
+			"2023-04-13 14:25:27.18-04:00",
+		},
+		{
+			"second date",
+			"from 2024-05-01T10:00:00Z to 2024-05-02T12:30:00Z",
+			2,
+			"2024-05-02T12:30:00Z",
+			"2024-05-02 12:30:00Z",
+		},
+		{
+			"date only",
+			"release of 2024-05-01 is done",
+			1,
+			"2024-05-01",
+			"2024-05-01 00:00:00-05:00",
+		},
+		{
+			"after many date-like fragments",
+			strings.Repeat("12:3 2024-13 ", 2000) + "at 2024-06-01T10:00:00Z",
+			1,
+			"2024-06-01T10:00:00Z",
+			"2024-06-01 10:00:00Z",
+		},
+		{
+			"embedded in larger number is ignored",
+			"id=12024-05-01 at 2024-06-01",
+			1,
+			"2024-06-01",
+			"2024-06-01 00:00:00-05:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := ExtractDateLikeInput(tt.line, tt.nth, testLocation)
+			if !assert.True(t, ok, "no date found in %q", tt.line) {
+				return
+			}
+
+			assert.Equal(t, tt.wantText, tt.line[match.Start:match.End])
+			assert.True(t, date(t, tt.wantDate).Equal(match.Time), "expected %s, got %s", tt.wantDate, match.Time.Format(testLayout))
+		})
+	}
+}
+
+func TestExtractDateLikeInput_NotFound(t *testing.T) {
+	_, ok := ExtractDateLikeInput("no date in there, only 12345", 1, testLocation)
+	assert.False(t, ok)
+
+	_, ok = ExtractDateLikeInput("single 2024-05-01T10:00:00Z date", 2, testLocation)
+	assert.False(t, ok)
+}
+
+func Test_layoutToRegexp(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"2006-01-02T15:04:05Z07:00", `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:\d{2})`},
+		{"01-02|15:04:05.999999999", `\d{2}-\d{2}\|\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:[.,]\d{1,9})?`},
+		{"Jan _2 15:04:05.000", `[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}(?:[.,]\d+)?[.,]\d{3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			assert.Equal(t, tt.want, layoutToRegexp(tt.layout))
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateLayoutSourceBuiltin is the [DateLayout.Source] of the layouts shipped with the tooling.
+const DateLayoutSourceBuiltin = "builtin"
+
+// DateLayout is a layout tried by [ParseDateLikeInput] to parse a date.
+type DateLayout struct {
+	// Layout is the Go reference layout (see [time.Layout]) used to parse the date.
+	Layout string
+
+	// Zoned is true if the layout carries the timezone of the date (offset or abbreviation). Layouts
+	// that are not zoned (local) are interpreted in the timezone received by [ParseDateLikeInput].
+	Zoned bool
+
+	// Source is where the layout is coming from, [DateLayoutSourceBuiltin] or the path of the
+	// file it was loaded from.
+	Source string
+}
+
+// DateLayoutRegistry holds the layouts tried by [ParseDateLikeInput], zoned layouts are always
+// tried before local ones and within each group, layouts are tried in registration order.
+type DateLayoutRegistry struct {
+	zoned []DateLayout
+	local []DateLayout
+
+	// extraction is the alternation of all layouts, see extractionRegexp, reset on Add
+	extraction *regexp.Regexp
+}
+
+// NewBuiltinDateLayoutRegistry returns a registry containing only the built-in layouts.
+func NewBuiltinDateLayoutRegistry() *DateLayoutRegistry {
+	registry := &DateLayoutRegistry{}
+	for _, layout := range layouts {
+		registry.Add(DateLayout{Layout: layout, Zoned: true, Source: DateLayoutSourceBuiltin})
+	}
+
+	for _, layout := range localLayouts {
+		registry.Add(DateLayout{Layout: layout, Zoned: false, Source: DateLayoutSourceBuiltin})
+	}
+
+	return registry
+}
+
+func (r *DateLayoutRegistry) Add(layout DateLayout) {
+	r.extraction = nil
+
+	if layout.Zoned {
+		r.zoned = append(r.zoned, layout)
+	} else {
+		r.local = append(r.local, layout)
+	}
+}
+
+func (r *DateLayoutRegistry) Zoned() []DateLayout {
+	return r.zoned
+}
+
+func (r *DateLayoutRegistry) Local() []DateLayout {
+	return r.local
+}
+
+// extractionRegexp returns the alternation of the regular expressions of all the layouts, it
+// finds where a date may start in a line with a single scan, see [ExtractAllDateLikeInputs].
+func (r *DateLayoutRegistry) extractionRegexp() *regexp.Regexp {
+	if r.extraction != nil {
+		return r.extraction
+	}
+
+	alternatives := make([]string, 0, len(r.zoned)+len(r.local))
+	for _, layout := range append(append([]DateLayout{}, r.zoned...), r.local...) {
+		alternatives = append(alternatives, `(?:`+layoutToRegexp(layout.Layout)+`)`)
+	}
+
+	r.extraction = regexp.MustCompile(strings.Join(alternatives, "|"))
+	return r.extraction
+}
+
+// dateLayoutsConfigLoader reads the user date layouts file, see [DefaultDateLayoutsFile]
+var dateLayoutsConfigLoader = &ConfigLoader[dateLayoutsConfig]{Tool: "date_layouts"}
+
+// DefaultDateLayoutsFile returns the path of the user date layouts file which is
+// `$HOME/.config/streamingfast/tooling/date_layouts.yaml`, overridden by the
+// `SFTOOL_DATE_LAYOUTS_CONFIG` environment variable.
+func DefaultDateLayoutsFile() (string, error) {
+	return dateLayoutsConfigLoader.ResolveFile(ConfigOverrides{})
+}
+
+var defaultDateLayouts = sync.OnceValue(func() *DateLayoutRegistry {
+	return loadDateLayouts(os.Stderr)
+})
+
+// loadDateLayouts returns the built-in layouts merged with the user layouts of
+// [DefaultDateLayoutsFile]. An invalid user file is reported on `warnings` and only the
+// built-in layouts are returned so that a broken file does not prevent parsing dates.
+func loadDateLayouts(warnings io.Writer) *DateLayoutRegistry {
+	if _, err := DefaultDateLayoutsFile(); err != nil {
+		// Without a home directory, there is no user layouts to load
+		return NewBuiltinDateLayoutRegistry()
+	}
+
+	registry := NewBuiltinDateLayoutRegistry()
+
+	loaded, err := dateLayoutsConfigLoader.Load(ConfigOverrides{})
+	if err == nil {
+		err = registry.addConfig(loaded.Config, loaded.File)
+	}
+
+	if err != nil {
+		fmt.Fprintf(warnings, "Ignoring user date layouts, only built-in layouts are used: %s\n", err)
+		return NewBuiltinDateLayoutRegistry()
+	}
+
+	return registry
+}
+
+// DefaultDateLayouts returns the registry used by [ParseDateLikeInput], the built-in layouts
+// merged with the user layouts found in [DefaultDateLayoutsFile], if it exists. The file is
+// loaded once, on first use.
+func DefaultDateLayouts() *DateLayoutRegistry {
+	return defaultDateLayouts()
+}
+
+type dateLayoutsConfig struct {
+	Layouts []dateLayoutConfig `yaml:"layouts"`
+}
+
+type dateLayoutConfig struct {
+	Layout   string `yaml:"layout"`
+	Strftime string `yaml:"strftime"`
+	Zoned    *bool  `yaml:"zoned"`
+}
+
+// LoadFile adds the layouts defined in the YAML file at `path` to the registry. The file is
+// of the form:
+//
+//	layouts:
+//	  # Go reference layout with its timezone
+//	  - layout: "2006-01-02 15:04:05.000 MST"
+//	    zoned: true
+//	  # strftime pattern without timezone, interpreted in the timezone if unset
+//	  - strftime: "%d/%m/%Y %H:%M:%S"
+//	    zoned: false
+//
+// Each layout must define exactly one of `layout` or `strftime` as well as `zoned`. The error
+// wraps [fs.ErrNotExist] if the file does not exist.
+func (r *DateLayoutRegistry) LoadFile(path string) error {
+	loaded, err := dateLayoutsConfigLoader.Load(ConfigOverrides{File: path})
+	if err != nil {
+		return err
+	}
+
+	return r.addConfig(loaded.Config, path)
+}
+
+func (r *DateLayoutRegistry) addConfig(config *dateLayoutsConfig, source string) error {
+	for i, definition := range config.Layouts {
+		layout, err := definition.toDateLayout(source)
+		if err != nil {
+			return fmt.Errorf("invalid date layouts file %q: layout #%d: %w", source, i+1, err)
+		}
+
+		r.Add(layout)
+	}
+
+	return nil
+}
+
+func (c dateLayoutConfig) toDateLayout(source string) (out DateLayout, err error) {
+	if (c.Layout == "") == (c.Strftime == "") {
+		return out, errors.New("exactly one of 'layout' or 'strftime' must be defined")
+	}
+
+	if c.Zoned == nil {
+		return out, errors.New("'zoned' must be defined, use true if the layout contains the timezone, false otherwise")
+	}
+
+	layout := c.Layout
+	if c.Strftime != "" {
+		layout, err = StrftimeToLayout(c.Strftime)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	// A layout without any reference component formats to itself, it's most probably a mistake
+	if layoutProbeTime.Format(layout) == layout {
+		return out, fmt.Errorf("layout %q does not contain any Go reference component (e.g. 2006, 01, 02, 15, 04, 05)", layout)
+	}
+
+	return DateLayout{Layout: layout, Zoned: *c.Zoned, Source: source}, nil
+}
+
+// layoutProbeTime has no component in common with the Go reference time
+var layoutProbeTime = time.Date(1999, time.November, 28, 9, 47, 38, 123456789, time.FixedZone("XYZ", 3*60*60))
+
+var strftimeDirectives = map[string]string{
+	"Y":  "2006",
+	"y":  "06",
+	"m":  "01",
+	"-m": "1",
+	"d":  "02",
+	"-d": "2",
+	"e":  "_2",
+	"j":  "002",
+	"H":  "15",
+	"I":  "03",
+	"-I": "3",
+	"M":  "04",
+	"S":  "05",
+	"f":  "000000",
+	"L":  "000",
+	"N":  "000000000",
+	"p":  "PM",
+	"b":  "Jan",
+	"h":  "Jan",
+	"B":  "January",
+	"a":  "Mon",
+	"A":  "Monday",
+	"z":  "-0700",
+	":z": "-07:00",
+	"Z":  "MST",
+	"F":  "2006-01-02",
+	"T":  "15:04:05",
+	"R":  "15:04",
+	"D":  "01/02/06",
+	"%":  "%",
+}
+
+// StrftimeToLayout converts a strftime pattern like `%Y-%m-%d %H:%M:%S` to its Go reference
+// layout equivalent. Fractional seconds directives (`%f` for microseconds, `%L` for milliseconds
+// and `%N` for nanoseconds) must be preceded by a `.` or a `,` like in `%S.%f`.
+func StrftimeToLayout(pattern string) (string, error) {
+	builder := strings.Builder{}
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			builder.WriteByte(pattern[i])
+			continue
+		}
+
+		if i+1 >= len(pattern) {
+			return "", fmt.Errorf("strftime pattern %q ends with a lone %%", pattern)
+		}
+
+		directive := pattern[i+1 : i+2]
+		if (directive == "-" || directive == ":") && i+2 < len(pattern) {
+			directive = pattern[i+1 : i+3]
+		}
+
+		replacement, found := strftimeDirectives[directive]
+		if !found {
+			return "", fmt.Errorf("strftime pattern %q contains unsupported directive %%%s", pattern, directive)
+		}
+
+		builder.WriteString(replacement)
+		i += len(directive)
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrftimeToLayout(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		want      string
+		assertion require.ErrorAssertionFunc
+	}{
+		{"%Y-%m-%d %H:%M:%S", "2006-01-02 15:04:05", require.NoError},
+		{"%d/%m/%y %I:%M %p", "02/01/06 03:04 PM", require.NoError},
+		{"%a, %d %b %Y %T %z", "Mon, 02 Jan 2006 15:04:05 -0700", require.NoError},
+		{"%FT%T.%f%:z", "2006-01-02T15:04:05.000000-07:00", require.NoError},
+		{"%-d %B %Y %R %Z", "2 January 2006 15:04 MST", require.NoError},
+		{"100%% at %H", "100% at 15", require.NoError},
+		{"%Q", "", require.Error},
+		{"%Y %", "", require.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := StrftimeToLayout(tt.pattern)
+			tt.assertion(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateLayoutRegistry_LoadFile(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "date_layouts.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		path := writeFile(t, `
+layouts:
+  - layout: "2006/01/02 15h04 MST"
+    zoned: true
+  - strftime: "%d.%m.%Y %H:%M"
+    zoned: false
+`)
+
+		registry := &DateLayoutRegistry{}
+		require.NoError(t, registry.LoadFile(path))
+
+		assert.Equal(t, []DateLayout{{Layout: "2006/01/02 15h04 MST", Zoned: true, Source: path}}, registry.Zoned())
+		assert.Equal(t, []DateLayout{{Layout: "02.01.2006 15:04", Zoned: false, Source: path}}, registry.Local())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		registry := &DateLayoutRegistry{}
+		require.NoError(t, registry.LoadFile(writeFile(t, "")))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		registry := &DateLayoutRegistry{}
+		assert.ErrorIs(t, registry.LoadFile(filepath.Join(t.TempDir(), "missing.yaml")), fs.ErrNotExist)
+	})
+
+	invalids := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"missing zoned", "layouts:\n  - layout: \"2006\"\n", "'zoned' must be defined"},
+		{"both kinds", "layouts:\n  - layout: \"2006\"\n    strftime: \"%Y\"\n    zoned: true\n", "exactly one of"},
+		{"no component", "layouts:\n  - layout: \"yyyy-mm-dd\"\n    zoned: false\n", "does not contain any Go reference component"},
+		{"unknown field", "layouts:\n  - format: \"2006\"\n    zoned: false\n", `unknown key "layouts[0].format"`},
+	}
+
+	for _, tt := range invalids {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &DateLayoutRegistry{}
+			assert.ErrorContains(t, registry.LoadFile(writeFile(t, tt.content)), tt.want)
+		})
+	}
+}
+
+func Test_loadDateLayouts(t *testing.T) {
+	writeUserFile := func(t *testing.T, content string) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+		if content == "" {
+			return
+		}
+
+		file, err := DefaultDateLayoutsFile()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	}
+
+	builtin := NewBuiltinDateLayoutRegistry()
+
+	t.Run("missing", func(t *testing.T) {
+		writeUserFile(t, "")
+
+		warnings := &bytes.Buffer{}
+		assert.Equal(t, builtin, loadDateLayouts(warnings))
+		assert.Empty(t, warnings.String())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		writeUserFile(t, "layouts:\n  - layout: \"02.01.2006 15:04\"\n    zoned: false\n")
+
+		warnings := &bytes.Buffer{}
+		registry := loadDateLayouts(warnings)
+		assert.Empty(t, warnings.String())
+		assert.Len(t, registry.Local(), len(builtin.Local())+1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		writeUserFile(t, "layouts:\n  - layout: \"02.01.2006 15:04\"\n    zoned: false\n  - layout: \"yyyy\"\n    zoned: false\n")
+
+		warnings := &bytes.Buffer{}
+		assert.Equal(t, builtin, loadDateLayouts(warnings))
+		assert.Contains(t, warnings.String(), "Ignoring user date layouts, only built-in layouts are used: invalid date layouts file")
+	})
+}
+
+func Test_ParseDateLikeInput_UserLayouts(t *testing.T) {
+	registry := NewBuiltinDateLayoutRegistry()
+	registry.Add(DateLayout{Layout: "2006/01/02 15h04 -0700", Zoned: true, Source: "test"})
+	registry.Add(DateLayout{Layout: "02.01.2006 15:04", Zoned: false, Source: "test"})
+
+	previous := defaultDateLayouts
+	defaultDateLayouts = func() *DateLayoutRegistry { return registry }
+	defer func() { defaultDateLayouts = previous }()
+
+	got, parsedFrom, ok := ParseDateLikeInput("2024/03/05 10h30 +0200", DateLikeHintNone, testLocation)
+	require.True(t, ok)
+	assert.Equal(t, DateParsedFromLayout, parsedFrom)
+	assert.True(t, date(t, "2024-03-05 10:30:00+02:00").Equal(got), "got %s", got.Format(testLayout))
+
+	got, _, ok = ParseDateLikeInput("05.03.2024 10:30", DateLikeHintNone, time.UTC)
+	require.True(t, ok)
+	assert.True(t, date(t, "2024-03-05 10:30:00Z").Equal(got), "got %s", got.Format(testLayout))
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DateZoneSource tells where the timezone of a date parsed by [ExplainDateLikeInput] comes from.
+type DateZoneSource string
+
+const (
+	// DateZoneSourceInputOffset is a numeric offset found in the input like `-0400` or `Z`.
+	DateZoneSourceInputOffset DateZoneSource = "input-offset"
+
+	// DateZoneSourceInputAbbreviation is a zone abbreviation found in the input like `EDT` or `CET`,
+	// the trace's zone detail tells how the abbreviation was resolved.
+	DateZoneSourceInputAbbreviation DateZoneSource = "input-abbreviation"
+
+	// DateZoneSourceTimezoneIfUnset is the timezone received by [ParseDateLikeInput], used when the
+	// input has no timezone information (local layouts and relative days like `yesterday`).
+	DateZoneSourceTimezoneIfUnset DateZoneSource = "timezone-if-unset"
+
+	// DateZoneSourceUnixTimestamp is UTC, used for Unix timestamps which are timezone less.
+	DateZoneSourceUnixTimestamp DateZoneSource = "unix-timestamp"
+
+	// DateZoneSourceNow is the timezone of the current time, used for `now`.
+	DateZoneSourceNow DateZoneSource = "now"
+)
+
+// DateParseTrace describes how [ExplainDateLikeInput] parsed a date.
+type DateParseTrace struct {
+	Input      string
+	ParsedFrom DateParsedFrom
+
+	// Layout is the layout that matched, nil if the date was not parsed from a layout.
+	Layout *DateLayout
+
+	Zone       DateZoneSource
+	ZoneDetail string
+
+	// EpochUnit is `seconds`, `milliseconds`, `microseconds` or `nanoseconds` when parsed from a Unix timestamp, EpochDecision
+	// tells if the unit came from the hint or from the magnitude heuristic.
+	EpochUnit     string
+	EpochDecision string
+
+	// FilledComponents are the date components (`year`, `month`, `day`) missing from the
+	// input that were filled using the current date.
+	FilledComponents []string
+
+	// Relative is the relative part of a relative expression, either the offset applied to
+	// RelativeBase (e.g. `-2h`) or the relative day (e.g. `yesterday 15:00`).
+	Relative     string
+	RelativeBase *DateParseTrace
+
+	// Err is the reason why a date that matched a layout could not be parsed, like an
+	// ambiguous timezone abbreviation, nil otherwise.
+	Err error
+}
+
+// Lines returns a human readable description of the trace, one fact per line.
+func (t *DateParseTrace) Lines() (out []string) {
+	out = append(out, fmt.Sprintf("parsed from: %s", strings.ToLower(t.ParsedFrom.String())))
+
+	if t.Layout != nil {
+		out = append(out, fmt.Sprintf("layout: %q (%s, %s)", t.Layout.Layout, zonedOrLocal(t.Layout.Zoned), t.Layout.Source))
+	}
+
+	if t.EpochUnit != "" {
+		out = append(out, fmt.Sprintf("epoch unit: %s (%s)", t.EpochUnit, t.EpochDecision))
+	}
+
+	if t.Zone != "" {
+		zone := fmt.Sprintf("zone: %s", t.Zone)
+		if t.ZoneDetail != "" {
+			zone += " (" + t.ZoneDetail + ")"
+		}
+
+		out = append(out, zone)
+	}
+
+	if len(t.FilledComponents) > 0 {
+		out = append(out, fmt.Sprintf("filled from current date: %s", strings.Join(t.FilledComponents, ", ")))
+	}
+
+	if t.Relative != "" {
+		out = append(out, fmt.Sprintf("relative: %s", t.Relative))
+	}
+
+	if t.Err != nil {
+		out = append(out, fmt.Sprintf("error: %s", t.Err))
+	}
+
+	if t.RelativeBase != nil {
+		out = append(out, fmt.Sprintf("relative base: %q", t.RelativeBase.Input))
+		for _, line := range t.RelativeBase.Lines() {
+			out = append(out, "  "+line)
+		}
+	}
+
+	return out
+}
+
+func (t *DateParseTrace) String() string {
+	return strings.Join(t.Lines(), "\n")
+}
+
+func zonedOrLocal(zoned bool) string {
+	if zoned {
+		return "zoned"
+	}
+
+	return "local"
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainDateLikeInput(t *testing.T) {
+	tests := []struct {
+		element string
+		hint    DateLikeHint
+		want    []string
+	}{
+		{
+			"1600446733",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: timestamp",
+				"epoch unit: seconds (heuristic, value is lower or equal to 32503683661 (year 3000 in seconds))",
+				"zone: unix-timestamp (UTC)",
+			},
+		},
+		{
+			"1600446733",
+			DateLikeHintUnixMilliseconds,
+			[]string{
+				"parsed from: timestamp",
+				"epoch unit: milliseconds (forced by hint)",
+				"zone: unix-timestamp (UTC)",
+			},
+		},
+		{
+			"2023-04-13T14:25:27.180-0400",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: layout",
+				`layout: "2006-01-02T15:04:05.999999999-0700" (zoned, builtin)`,
+				"zone: input-offset",
+			},
+		},
+		{
+			"15:30 UTC",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: layout",
+				`layout: "15:04 MST" (zoned, builtin)`,
+				`zone: input-abbreviation ("UTC" loaded from timezone database)`,
+				"filled from current date: year, month, day",
+			},
+		},
+		{
+			"11-29|08:28:27.718",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: layout",
+				`layout: "01-02|15:04:05.999999999" (local, builtin)`,
+				"zone: timezone-if-unset (EST)",
+				"filled from current date: year",
+			},
+		},
+		{
+			"2024-05-01+36h",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: relative",
+				"relative: +36h0m0s",
+				`relative base: "2024-05-01"`,
+				"  parsed from: layout",
+				`  layout: "2006-01-02" (local, builtin)`,
+				"  zone: timezone-if-unset (EST)",
+			},
+		},
+		{
+			"3 days ago",
+			DateLikeHintNone,
+			[]string{
+				"parsed from: relative",
+				"relative: -3d",
+				`relative base: "now"`,
+				"  parsed from: layout",
+				"  zone: timezone-if-unset (EST)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			_, trace, ok := ExplainDateLikeInput(tt.element, tt.hint, testLocation)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.want, trace.Lines())
+		})
+	}
+}
+
+func TestExplainDateLikeInput_Invalid(t *testing.T) {
+	_, trace, ok := ExplainDateLikeInput("not a date", DateLikeHintNone, time.UTC)
+
+	assert.False(t, ok)
+	assert.NotNil(t, trace)
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrorPolicy defines what happens when a single element of a stream fails to be processed.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFailFast reports the error and stops processing with exit code 1, this is the default.
+	ErrorPolicyFailFast ErrorPolicy = "fail-fast"
+
+	// ErrorPolicySkip reports the error, drops the element and continues with the next one.
+	ErrorPolicySkip ErrorPolicy = "skip"
+
+	// ErrorPolicyPassthrough reports the error, outputs the element unchanged and continues
+	// with the next one.
+	ErrorPolicyPassthrough ErrorPolicy = "passthrough"
+)
+
+var errorPolicies = []ErrorPolicy{ErrorPolicyFailFast, ErrorPolicySkip, ErrorPolicyPassthrough}
+
+func ParseErrorPolicy(in string) (ErrorPolicy, error) {
+	for _, policy := range errorPolicies {
+		if strings.EqualFold(in, string(policy)) {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown error policy %q, valid values are %s", in, joinErrorPolicies(", "))
+}
+
+func joinErrorPolicies(sep string) string {
+	values := make([]string, len(errorPolicies))
+	for i, policy := range errorPolicies {
+		values[i] = string(policy)
+	}
+
+	return strings.Join(values, sep)
+}
+
+var errorPolicy = string(ErrorPolicyFailFast)
+
+// RegisterErrorPolicyFlags registers the shared `-on-error` flag on the received flag set. The
+// value is used by [NewErrorHandler] and [NewEmitter].
+func RegisterErrorPolicyFlags(flags FlagSet) {
+	flags.StringVar(&errorPolicy, "on-error", string(ErrorPolicyFailFast), fmt.Sprintf("What to do when an element cannot be processed, one of %s, errors are always reported on stderr", joinErrorPolicies(", ")))
+}
+
+// ErrorHandler applies an [ErrorPolicy] to the errors of the elements of a stream and
+// keeps track of how many elements failed.
+type ErrorHandler struct {
+	policy   ErrorPolicy
+	writer   io.Writer
+	failures int
+
+	// onFailFast is called before exiting when the policy is [ErrorPolicyFailFast]
+	onFailFast func()
+}
+
+// NewErrorHandler creates an [ErrorHandler] reporting to standard error using
+// the policy defined by the `-on-error` flag, see [RegisterErrorPolicyFlags].
+func NewErrorHandler() *ErrorHandler {
+	policy, err := ParseErrorPolicy(errorPolicy)
+	NoError(err, "invalid -on-error flag")
+
+	return NewErrorHandlerTo(os.Stderr, policy)
+}
+
+func NewErrorHandlerTo(writer io.Writer, policy ErrorPolicy) *ErrorHandler {
+	return &ErrorHandler{policy: policy, writer: writer}
+}
+
+func (h *ErrorHandler) Policy() ErrorPolicy {
+	return h.policy
+}
+
+func (h *ErrorHandler) Failures() int {
+	return h.failures
+}
+
+// ElementPosition locates an element in the input, see [ErrorHandler.Handle].
+type ElementPosition struct {
+	// Index is the position (1-based) of the element in the stream of elements
+	Index int
+
+	// Line is the input line (1-based) where the element starts when the input is line based
+	// (see [ElementLine]), 0 otherwise
+	Line int
+}
+
+// String returns `line <Line>` when the line is known and `element <Index>` otherwise.
+func (p ElementPosition) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d", p.Line)
+	}
+
+	return fmt.Sprintf("element %d", p.Index)
+}
+
+// Handle reports the error of `element` at `position` in the input and exits the process
+// with code 1 if the policy is [ErrorPolicyFailFast].
+func (h *ErrorHandler) Handle(position ElementPosition, element string, err error) {
+	h.failures++
+	fmt.Fprintf(h.writer, "%s: %q: %s\n", position, element, err)
+
+	if h.policy == ErrorPolicyFailFast {
+		if h.onFailFast != nil {
+			h.onFailFast()
+		}
+
+		os.Exit(1)
+	}
+}
+
+// ExitOnFailures exits the process if at least one element failed, the exit code
+// is the number of failed elements capped to 125 so that it does not collide with
+// codes reserved by shells.
+func (h *ErrorHandler) ExitOnFailures(total int) {
+	if h.failures == 0 {
+		return
+	}
+
+	fmt.Fprintf(h.writer, "%d element(s) out of %d failed\n", h.failures, total)
+	os.Exit(min(h.failures, 125))
+}
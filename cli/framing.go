@@ -0,0 +1,511 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Framing defines how a stream of bytes is split into the individual elements
+// returned by an [ArgumentScanner].
+type Framing string
+
+const (
+	// FramingLine splits the input on new lines, this is the default.
+	FramingLine Framing = "line"
+
+	// FramingNul splits the input on NUL bytes, like `find -print0` or `xargs -0`.
+	FramingNul Framing = "nul"
+
+	// FramingJSONLines reads one JSON document per line and extracts the element
+	// at the path defined by [ScanOptions.Field] (e.g. `.block.timestamp`).
+	FramingJSONLines Framing = "jsonl"
+
+	// FramingCSV reads comma separated records and extracts the column defined
+	// by [ScanOptions.Field]. Malformed quoted fields are invalid records.
+	FramingCSV Framing = "csv"
+
+	// FramingTSV reads tab separated records and extracts the column defined
+	// by [ScanOptions.Field]. Quotes are kept as is when they do not enclose a field.
+	FramingTSV Framing = "tsv"
+)
+
+var framings = []Framing{FramingLine, FramingNul, FramingJSONLines, FramingCSV, FramingTSV}
+
+// ScanOptions controls how reader based [ArgumentScanner] split their input.
+type ScanOptions struct {
+	Framing Framing
+
+	// Field is the JSON path when using [FramingJSONLines] and the column selector when
+	// using [FramingCSV] or [FramingTSV]. A column selector is either a 1-based column
+	// index or a column name, in which case the first record is treated as the header.
+	Field string
+}
+
+func (o ScanOptions) Validate() error {
+	switch o.Framing {
+	case "", FramingLine, FramingNul:
+		return nil
+
+	case FramingJSONLines:
+		_, err := parseJSONPath(o.Field)
+		return err
+
+	case FramingCSV, FramingTSV:
+		if o.Field == "" {
+			return fmt.Errorf("framing %q requires a column selector to be provided through -field", o.Framing)
+		}
+
+		if index, err := strconv.Atoi(o.Field); err == nil && index < 1 {
+			return fmt.Errorf("column index %d is invalid, columns are numbered from 1", index)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown framing %q, valid values are %s", o.Framing, joinFramings(", "))
+}
+
+var scanOptions = ScanOptions{Framing: FramingLine}
+
+// RegisterFramingFlags registers the shared `-framing` and `-field` flags on the received
+// flag set. The values are used by all [ArgumentScanner] reading from a stream created
+// afterwards through [NewArgumentScanner], [NewStdinArgumentScanner] and [NewFileArgumentScanner].
+func RegisterFramingFlags(flags FlagSet) {
+	flags.StringVar((*string)(&scanOptions.Framing), "framing", string(FramingLine), fmt.Sprintf("How standard input is split into elements, one of %s", joinFramings(", ")))
+	flags.StringVar(&scanOptions.Field, "field", "", "The JSON path (e.g. '.block.timestamp') to extract with '-framing jsonl' or the column (1-based index or header name) to extract with '-framing csv|tsv'")
+}
+
+// ExtractFramingFlags applies the `-framing` and `-field` flags (see [RegisterFramingFlags])
+// found in `args` and returns the other arguments, it's for the tools parsing their arguments
+// themselves. The `-flag value` and `-flag=value` forms are accepted with one or two dashes,
+// the arguments following `--` are kept as is.
+func ExtractFramingFlags(args []string) (rest []string, err error) {
+	framingFlags := flag.NewFlagSet("framing", flag.ContinueOnError)
+	RegisterFramingFlags(framingFlags)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(rest, args[i:]...), nil
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "framing" && name != "field") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("flag %s requires a value", arg)
+			}
+
+			i++
+			value = args[i]
+		}
+
+		if err := framingFlags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid flag %s: %w", arg, err)
+		}
+	}
+
+	return rest, nil
+}
+
+func joinFramings(sep string) string {
+	values := make([]string, len(framings))
+	for i, framing := range framings {
+		values[i] = string(framing)
+	}
+
+	return strings.Join(values, sep)
+}
+
+func newReaderArgumentScanner(reader io.Reader, options ScanOptions) ArgumentScanner {
+	NoError(options.Validate(), "invalid framing options")
+
+	switch options.Framing {
+	case FramingNul:
+		return newBufioArgumentScanner(reader, ScanNul)
+
+	case FramingJSONLines:
+		path, _ := parseJSONPath(options.Field)
+
+		return &jsonLinesArgumentScanner{lines: newBufioArgumentScanner(reader, bufio.ScanLines), path: path}
+
+	case FramingCSV, FramingTSV:
+		lines := &rawLinesReader{reader: reader, first: 1}
+
+		csvReader := csv.NewReader(lines)
+		csvReader.FieldsPerRecord = -1
+		csvReader.ReuseRecord = true
+		if options.Framing == FramingTSV {
+			// Quotes have no special meaning in most TSV exports, CSV malformed quoted fields are reported
+			csvReader.Comma = '\t'
+			csvReader.LazyQuotes = true
+		}
+
+		return &csvArgumentScanner{reader: csvReader, lines: lines, selector: options.Field, column: csvColumnUnresolved}
+	}
+
+	return &lineArgumentScanner{lines: newBufioArgumentScanner(reader, bufio.ScanLines)}
+}
+
+// lineArgumentScanner returns each line of its input, counting them
+type lineArgumentScanner struct {
+	lines *bufioArgumentScanner
+	line  int
+}
+
+func (s *lineArgumentScanner) ScanArgument() (string, bool) {
+	line, ok := s.lines.ScanArgument()
+	if ok {
+		s.line++
+	}
+
+	return line, ok
+}
+
+func (s *lineArgumentScanner) ElementLine() int {
+	return s.line
+}
+
+func newBufioArgumentScanner(reader io.Reader, split bufio.SplitFunc) *bufioArgumentScanner {
+	scanner := bufio.NewScanner(reader)
+	// Let's allow token as long as 50MiB
+	scanner.Buffer(nil, 50*1024*1024)
+	scanner.Split(split)
+
+	return (*bufioArgumentScanner)(scanner)
+}
+
+// ScanNul is a [bufio.SplitFunc] that returns each NUL terminated record, the
+// last record is returned even if it's not terminated by a NUL byte.
+func ScanNul(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[0:i], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+type jsonLinesArgumentScanner struct {
+	lines *bufioArgumentScanner
+	path  []jsonPathSegment
+	line  int
+	err   error
+}
+
+// ScanArgument returns the extracted field of the next JSON line, or the raw line if the
+// field cannot be extracted in which case the error is available through ElementError.
+func (s *jsonLinesArgumentScanner) ScanArgument() (string, bool) {
+	s.err = nil
+
+	for line, ok := s.lines.ScanArgument(); ok; line, ok = s.lines.ScanArgument() {
+		s.line++
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		value, err := extractJSONPath(line, s.path)
+		if err != nil {
+			s.err = fmt.Errorf("unable to extract field from JSON line %d: %w", s.line, err)
+			return line, true
+		}
+
+		return value, true
+	}
+
+	return "", false
+}
+
+func (s *jsonLinesArgumentScanner) ElementError() error {
+	return s.err
+}
+
+func (s *jsonLinesArgumentScanner) ElementLine() int {
+	return s.line
+}
+
+type jsonPathSegment struct {
+	key   string
+	index int
+}
+
+func (s jsonPathSegment) String() string {
+	if s.index >= 0 {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+
+	return "." + s.key
+}
+
+// parseJSONPath parses a path of the form `.block.header.timestamp` or `.logs[0].data`,
+// the empty path or `.` selects the whole document.
+func parseJSONPath(in string) (out []jsonPathSegment, err error) {
+	path := strings.TrimSpace(in)
+	if path == "" || path == "." {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end == -1 {
+				end = len(path) - 1
+			}
+
+			key := path[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSON path %q: empty key", in)
+			}
+
+			out = append(out, jsonPathSegment{key: key, index: -1})
+			path = path[end+1:]
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSON path %q: unterminated '['", in)
+			}
+
+			index, err := strconv.Atoi(path[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: index %q is not a positive integer", in, path[1:end])
+			}
+
+			out = append(out, jsonPathSegment{index: index})
+			path = path[end+1:]
+
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q: unexpected character %q", in, path[0])
+		}
+	}
+
+	return out, nil
+}
+
+func extractJSONPath(document string, path []jsonPathSegment) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	for i, segment := range path {
+		switch v := value.(type) {
+		case map[string]any:
+			if segment.index >= 0 {
+				return "", fmt.Errorf("path %s is an object, cannot index it with %s", formatJSONPath(path[:i]), segment)
+			}
+
+			child, found := v[segment.key]
+			if !found {
+				return "", fmt.Errorf("path %s not found", formatJSONPath(path[:i+1]))
+			}
+
+			value = child
+
+		case []any:
+			if segment.index < 0 {
+				return "", fmt.Errorf("path %s is an array, cannot access key %s", formatJSONPath(path[:i]), segment)
+			}
+
+			if segment.index >= len(v) {
+				return "", fmt.Errorf("path %s not found, array has %d element(s)", formatJSONPath(path[:i+1]), len(v))
+			}
+
+			value = v[segment.index]
+
+		default:
+			return "", fmt.Errorf("path %s not found, %s is a scalar value", formatJSONPath(path[:i+1]), formatJSONPath(path[:i]))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("re-encode value: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func formatJSONPath(path []jsonPathSegment) string {
+	if len(path) == 0 {
+		return "."
+	}
+
+	builder := strings.Builder{}
+	for _, segment := range path {
+		builder.WriteString(segment.String())
+	}
+
+	return builder.String()
+}
+
+const (
+	csvColumnUnresolved = -1
+
+	// csvColumnNotFound ends the scanning, the column selected by name is not in the header
+	csvColumnNotFound = -2
+)
+
+type csvArgumentScanner struct {
+	reader   *csv.Reader
+	lines    *rawLinesReader
+	selector string
+	column   int
+	line     int
+	err      error
+}
+
+// ScanArgument returns the selected column of the next record, or the raw record if it's
+// invalid in which case the error is available through ElementError.
+func (s *csvArgumentScanner) ScanArgument() (string, bool) {
+	s.err = nil
+	if s.column == csvColumnNotFound {
+		return "", false
+	}
+
+	record, err := s.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return "", false
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		s.line = parseErr.StartLine
+		s.err = fmt.Errorf("invalid record: %w", err)
+		return s.lines.between(parseErr.StartLine, parseErr.Line), true
+	}
+
+	NoError(err, "unable to read record from reader")
+	s.line, _ = s.reader.FieldPos(0)
+	s.lines.discardBefore(s.line)
+
+	if s.column == csvColumnUnresolved {
+		if index, err := strconv.Atoi(s.selector); err == nil {
+			s.column = index - 1
+		} else {
+			s.column = indexOf(record, s.selector)
+			if s.column == -1 {
+				s.column = csvColumnNotFound
+				s.err = fmt.Errorf("column %q not found in header [%s]", s.selector, strings.Join(record, ", "))
+
+				return s.rawRecord(record), true
+			}
+
+			// The header is not an element, move to next record
+			return s.ScanArgument()
+		}
+	}
+
+	if s.column >= len(record) {
+		s.err = fmt.Errorf("record at line %d has %d column(s), column %d requested", s.line, len(record), s.column+1)
+
+		return s.rawRecord(record), true
+	}
+
+	return record[s.column], true
+}
+
+func (s *csvArgumentScanner) ElementError() error {
+	return s.err
+}
+
+func (s *csvArgumentScanner) ElementLine() int {
+	return s.line
+}
+
+func (s *csvArgumentScanner) rawRecord(record []string) string {
+	return strings.Join(record, string(s.reader.Comma))
+}
+
+// rawLinesReader records the lines read through it so that the raw text of an invalid CSV
+// record can be returned, the lines before the last record read are discarded
+type rawLinesReader struct {
+	reader  io.Reader
+	lines   []string
+	first   int
+	partial []byte
+}
+
+func (r *rawLinesReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+
+	for data := p[:n]; len(data) > 0; {
+		end := bytes.IndexByte(data, '\n')
+		if end == -1 {
+			r.partial = append(r.partial, data...)
+			break
+		}
+
+		r.lines = append(r.lines, strings.TrimSuffix(string(append(r.partial, data[:end]...)), "\r"))
+		r.partial = r.partial[:0]
+		data = data[end+1:]
+	}
+
+	return n, err
+}
+
+// between returns the lines `from` to `to` (1-based, inclusive) joined by new lines
+func (r *rawLinesReader) between(from, to int) string {
+	lines := r.lines
+	if len(r.partial) > 0 {
+		lines = append(lines[:len(lines):len(lines)], string(r.partial))
+	}
+
+	from, to = max(from-r.first, 0), min(to-r.first+1, len(lines))
+	if from >= to {
+		return ""
+	}
+
+	return strings.Join(lines[from:to], "\n")
+}
+
+func (r *rawLinesReader) discardBefore(line int) {
+	if drop := min(line-r.first, len(r.lines)); drop > 0 {
+		r.lines = r.lines[drop:]
+		r.first += drop
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newReaderArgumentScanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		options ScanOptions
+		want    []string
+	}{
+		{
+			"line",
+			"a\nb\n\nc",
+			ScanOptions{Framing: FramingLine},
+			[]string{"a", "b", "", "c"},
+		},
+		{
+			"nul",
+			"./a b\x00./c\nd\x00./e",
+			ScanOptions{Framing: FramingNul},
+			[]string{"./a b", "./c\nd", "./e"},
+		},
+		{
+			"nul trailing terminator",
+			"a\x00b\x00",
+			ScanOptions{Framing: FramingNul},
+			[]string{"a", "b"},
+		},
+		{
+			"jsonl nested field",
+			`{"block":{"timestamp":"2024-05-01T00:00:00Z"}}` + "\n\n" + `{"block":{"timestamp":1714521600}}`,
+			ScanOptions{Framing: FramingJSONLines, Field: ".block.timestamp"},
+			[]string{"2024-05-01T00:00:00Z", "1714521600"},
+		},
+		{
+			"jsonl big number kept verbatim",
+			`{"value":123456789012345678901234567890}`,
+			ScanOptions{Framing: FramingJSONLines, Field: "value"},
+			[]string{"123456789012345678901234567890"},
+		},
+		{
+			"jsonl array index",
+			`{"logs":[{"data":"0xab"},{"data":"0xcd"}]}`,
+			ScanOptions{Framing: FramingJSONLines, Field: ".logs[1].data"},
+			[]string{"0xcd"},
+		},
+		{
+			"jsonl whole document",
+			`{"a": [1, 2]}`,
+			ScanOptions{Framing: FramingJSONLines},
+			[]string{`{"a":[1,2]}`},
+		},
+		{
+			"csv column index",
+			"1,a\n2,\"b,c\"\n",
+			ScanOptions{Framing: FramingCSV, Field: "2"},
+			[]string{"a", "b,c"},
+		},
+		{
+			"csv column name",
+			"id,size\n1,10 MiB\n2,12 KiB\n",
+			ScanOptions{Framing: FramingCSV, Field: "size"},
+			[]string{"10 MiB", "12 KiB"},
+		},
+		{
+			"tsv column index",
+			"1\tab\n2\tcd\n",
+			ScanOptions{Framing: FramingTSV, Field: "1"},
+			[]string{"1", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := newReaderArgumentScanner(strings.NewReader(tt.input), tt.options)
+
+			var got []string
+			for element, ok := scanner.ScanArgument(); ok; element, ok = scanner.ScanArgument() {
+				got = append(got, element)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseJSONPath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []jsonPathSegment
+		wantErr string
+	}{
+		{"", nil, ""},
+		{".", nil, ""},
+		{".block.timestamp", []jsonPathSegment{{key: "block", index: -1}, {key: "timestamp", index: -1}}, ""},
+		{"block", []jsonPathSegment{{key: "block", index: -1}}, ""},
+		{".logs[0]", []jsonPathSegment{{key: "logs", index: -1}, {index: 0}}, ""},
+		{"[2].a", []jsonPathSegment{{index: 2}, {key: "a", index: -1}}, ""},
+		{".a..b", nil, `invalid JSON path ".a..b": empty key`},
+		{".a[", nil, `invalid JSON path ".a[": unterminated '['`},
+		{".a[x]", nil, `invalid JSON path ".a[x]": index "x" is not a positive integer`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseJSONPath(tt.in)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_extractJSONPath(t *testing.T) {
+	path, err := parseJSONPath(".block.number")
+	require.NoError(t, err)
+
+	_, err = extractJSONPath(`{"block":{}}`, path)
+	assert.EqualError(t, err, "path .block.number not found")
+
+	_, err = extractJSONPath(`{"block":"abc"}`, path)
+	assert.EqualError(t, err, "path .block.number not found, .block is a scalar value")
+
+	_, err = extractJSONPath(`{"block":`, path)
+	assert.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestScanElement_InvalidRecords(t *testing.T) {
+	type element struct {
+		value string
+		err   string
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		options ScanOptions
+		want    []element
+	}{
+		{
+			"jsonl invalid line",
+			`{"a":1}` + "\n" + `not json` + "\n" + `{"a":3}`,
+			ScanOptions{Framing: FramingJSONLines, Field: ".a"},
+			[]element{{"1", ""}, {"not json", "unable to extract field from JSON line 2"}, {"3", ""}},
+		},
+		{
+			"csv too few columns",
+			"a,b\nc\nd,e",
+			ScanOptions{Framing: FramingCSV, Field: "2"},
+			[]element{{"b", ""}, {"c", "record at line 2 has 1 column(s), column 2 requested"}, {"e", ""}},
+		},
+		{
+			"csv header not found",
+			"a,b\nc,d",
+			ScanOptions{Framing: FramingCSV, Field: "z"},
+			[]element{{"a,b", `column "z" not found in header [a, b]`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := newReaderArgumentScanner(strings.NewReader(tt.input), tt.options)
+
+			var got []element
+			for value, ok, err := ScanElement(scanner); ok; value, ok, err = ScanElement(scanner) {
+				if err != nil {
+					require.Contains(t, err.Error(), tt.want[len(got)].err)
+					got = append(got, element{value, tt.want[len(got)].err})
+					continue
+				}
+
+				got = append(got, element{value, ""})
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestElementLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		options ScanOptions
+		want    []int
+	}{
+		{"lines", "a\n\nb\n", ScanOptions{Framing: FramingLine}, []int{1, 2, 3}},
+		{"nul", "a\x00b", ScanOptions{Framing: FramingNul}, []int{0, 0}},
+		{"jsonl blank lines", `{"a":1}` + "\n\n  \n" + `{"a":2}`, ScanOptions{Framing: FramingJSONLines, Field: ".a"}, []int{1, 4}},
+		{"csv header", "name\nx\ny", ScanOptions{Framing: FramingCSV, Field: "name"}, []int{2, 3}},
+		{"csv multi-line field", "\"a\nb\",1\nc,2", ScanOptions{Framing: FramingCSV, Field: "2"}, []int{1, 3}},
+		{"csv invalid record", "a,1\nb\"c\",2\nd,3", ScanOptions{Framing: FramingCSV, Field: "2"}, []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := newReaderArgumentScanner(strings.NewReader(tt.input), tt.options)
+
+			var got []int
+			for _, ok, _ := ScanElement(scanner); ok; _, ok, _ = ScanElement(scanner) {
+				got = append(got, ElementLine(scanner))
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmitResults_CSVPassthrough(t *testing.T) {
+	output := bytes.NewBuffer(nil)
+	errorsOutput := bytes.NewBuffer(nil)
+
+	scanner := newReaderArgumentScanner(strings.NewReader("name,value\na,1\n\"b\"x,2\n\"c\nd\"e,3\nf,4\n"), ScanOptions{Framing: FramingCSV, Field: "value"})
+	emitter := NewEmitterTo(output, OutputFormatText, NewErrorHandlerTo(errorsOutput, ErrorPolicyPassthrough))
+
+	count := emitResults(emitter, scanner, Converter(func(element string) (string, string, error) { return "=" + element, "", nil }).Results(), nil)
+
+	assert.Equal(t, 4, count)
+	assert.Equal(t, "=1\n\"b\"x,2\n\"c\nd\"e,3\n=4\n", output.String())
+	assert.Equal(t, ""+
+		`line 3: "\"b\"x,2": invalid record: parse error on line 3, column 3: extraneous or missing " in quoted-field`+"\n"+
+		`line 4: "\"c\nd\"e,3": invalid record: record on line 4; parse error on line 5, column 2: extraneous or missing " in quoted-field`+"\n",
+		errorsOutput.String())
+}
+
+func TestExtractFramingFlags(t *testing.T) {
+	defer func() { scanOptions = ScanOptions{Framing: FramingLine} }()
+
+	rest, err := ExtractFramingFlags([]string{"--framing", "jsonl", "1", "-field=.a", "--", "-framing", "csv"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "--", "-framing", "csv"}, rest)
+	assert.Equal(t, ScanOptions{Framing: FramingJSONLines, Field: ".a"}, scanOptions)
+
+	_, err = ExtractFramingFlags([]string{"1", "-field"})
+	assert.EqualError(t, err, "flag -field requires a value")
+}
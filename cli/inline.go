@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenMatcher finds the tokens of `line` that a converter can rewrite, returning their
+// `[start, end)` positions like [regexp.Regexp.FindAllStringIndex].
+type TokenMatcher func(line string) [][]int
+
+var inlineFlag bool
+
+// RegisterInlineFlags registers the shared `-inline` flag on the received flag set, see
+// [InlineEnabled] and [InlineConverter].
+func RegisterInlineFlags(flags FlagSet) {
+	flags.BoolVar(&inlineFlag, "inline", false, "Rewrite only the matching tokens found inside each element (e.g. a log line) and keep the rest of it untouched")
+}
+
+func InlineEnabled() bool {
+	return inlineFlag
+}
+
+// InlineConverter returns a [Converter] that replaces each token of the element found by
+// `matcher` with the output of `converter`, see [RewriteTokens].
+func InlineConverter(matcher TokenMatcher, converter Converter) Converter {
+	return func(element string) (string, string, error) {
+		return RewriteTokens(element, matcher, converter), "inline", nil
+	}
+}
+
+// RewriteTokens replaces each token of `line` found by `matcher` with the output of
+// `converter`, tokens that fail to convert are kept as is.
+func RewriteTokens(line string, matcher TokenMatcher, converter Converter) string {
+	builder := strings.Builder{}
+
+	end := 0
+	for _, token := range matcher(line) {
+		output, _, err := converter(line[token[0]:token[1]])
+		if err != nil {
+			continue
+		}
+
+		builder.WriteString(line[end:token[0]])
+		builder.WriteString(output)
+		end = token[1]
+	}
+
+	builder.WriteString(line[end:])
+	return builder.String()
+}
+
+// RegexpTokenMatcher matches the tokens of `expression` that are not glued to a word
+// character or a `.` so that `12` is not found in `v1.12`, `a12` or `12ms`.
+func RegexpTokenMatcher(expression *regexp.Regexp) TokenMatcher {
+	return func(line string) (out [][]int) {
+		for offset := 0; offset < len(line); {
+			location := expression.FindStringIndex(line[offset:])
+			if location == nil {
+				break
+			}
+
+			start, end := offset+location[0], offset+location[1]
+			if end == start {
+				offset = start + 1
+				continue
+			}
+
+			if isTokenBoundaryAt(line, start-1) && isTokenBoundaryAt(line, end) {
+				out = append(out, []int{start, end})
+				offset = end
+				continue
+			}
+
+			offset = start + 1
+		}
+
+		return out
+	}
+}
+
+func isTokenBoundaryAt(in string, index int) bool {
+	if index < 0 || index >= len(in) {
+		return true
+	}
+
+	c := in[index]
+	return !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '.' || c >= 0x80)
+}
+
+// CombineTokenMatchers returns the non-overlapping tokens of all `matchers`, the earliest
+// token wins and the longest one when multiple start at the same position.
+func CombineTokenMatchers(matchers ...TokenMatcher) TokenMatcher {
+	return func(line string) (out [][]int) {
+		var tokens [][]int
+		for _, matcher := range matchers {
+			tokens = append(tokens, matcher(line)...)
+		}
+
+		sort.Slice(tokens, func(i, j int) bool {
+			if tokens[i][0] == tokens[j][0] {
+				return tokens[i][1] > tokens[j][1]
+			}
+
+			return tokens[i][0] < tokens[j][0]
+		})
+
+		end := 0
+		for _, token := range tokens {
+			if token[0] < end {
+				continue
+			}
+
+			out = append(out, token)
+			end = token[1]
+		}
+
+		return out
+	}
+}
+
+// DecimalTokenMatcher matches unsigned decimal numbers like `1234`.
+var DecimalTokenMatcher = RegexpTokenMatcher(regexp.MustCompile(`[0-9]+`))
+
+// HexTokenMatcher matches `0x` prefixed hexadecimal numbers like `0xdeadbeef`.
+var HexTokenMatcher = RegexpTokenMatcher(regexp.MustCompile(`0[xX][0-9a-fA-F]+`))
+
+// BytesTokenMatcher matches numbers followed by a bytes unit, optionally separated by a space
+// and possibly a rate, like `1024 bytes`, `1536MB` or `12 MiB/s`. Bare numbers are not matched
+// as nothing tells they are bytes.
+var BytesTokenMatcher = RegexpTokenMatcher(regexp.MustCompile(`[0-9]+(?:\.[0-9]+)? ?` + bytesUnitsExpression() + `(?:/s)?`))
+
+func bytesUnitsExpression() string {
+	names := []string{"bytes", "byte"}
+	for _, units := range [][]ByteUnit{BinaryByteUnits, DecimalByteUnits} {
+		for _, unit := range units {
+			names = append(names, regexp.QuoteMeta(unit.Name))
+		}
+	}
+
+	// Longest first so `KiB` is preferred over `B` and `bytes` over `byte`
+	sort.SliceStable(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	return `(?:` + strings.Join(names, "|") + `)`
+}
+
+// DurationTokenMatcher matches compact durations like `150ms`, `1h30m` or `2d`.
+var DurationTokenMatcher = RegexpTokenMatcher(regexp.MustCompile(`(?:[0-9]+(?:\.[0-9]+)?(?:ns|us|µs|μs|ms|s|m|h|d|w))+`))
+
+// epochTokenMatcher matches the Unix timestamps in seconds (10 digits), milliseconds (13 digits),
+// microseconds (16 digits) or nanoseconds (19 digits) between [inlineEpochMin] and [inlineEpochMax],
+// with an optional fraction like `1700000000.123`. Other numbers, like a block number, are left alone.
+var epochTokenMatcher = func() TokenMatcher {
+	candidates := RegexpTokenMatcher(regexp.MustCompile(`[0-9]{10,19}(?:\.[0-9]+)?`))
+
+	return func(line string) (out [][]int) {
+		for _, token := range candidates(line) {
+			if isPlausibleEpoch(line[token[0]:token[1]]) {
+				out = append(out, token)
+			}
+		}
+
+		return out
+	}
+}()
+
+// inlineEpochMin and inlineEpochMax bound the Unix timestamps rewritten by `-inline`, from
+// 2001-09-09 (first 10 digits timestamp in seconds) to 2100-01-01, both in seconds.
+const (
+	inlineEpochMin = 1_000_000_000
+	inlineEpochMax = 4_102_444_800
+)
+
+func isPlausibleEpoch(token string) bool {
+	digits, _, _ := strings.Cut(token, ".")
+
+	var divisor uint64
+	switch len(digits) {
+	case 10:
+		divisor = 1
+	case 13:
+		divisor = 1_000
+	case 16:
+		divisor = 1_000_000
+	case 19:
+		divisor = 1_000_000_000
+	default:
+		return false
+	}
+
+	value, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	seconds := value / divisor
+	return seconds >= inlineEpochMin && seconds < inlineEpochMax
+}
+
+// DateTokenMatcher matches the dates found by [ExtractAllDateLikeInputs] as well as Unix
+// timestamps in seconds, milliseconds, microseconds or nanoseconds between 2001 and 2100.
+func DateTokenMatcher(timezoneIfUnset *time.Location) TokenMatcher {
+	return CombineTokenMatchers(epochTokenMatcher, func(line string) (out [][]int) {
+		for _, match := range ExtractAllDateLikeInputs(line, timezoneIfUnset) {
+			out = append(out, []int{match.Start, match.End})
+		}
+
+		return out
+	})
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteTokens(t *testing.T) {
+	upper := func(element string) (string, string, error) {
+		return "<" + strings.ToUpper(element) + ">", "", nil
+	}
+
+	tests := []struct {
+		name    string
+		line    string
+		matcher TokenMatcher
+		want    string
+	}{
+		{"decimal", "block=42 size 1000, v1.2 a12 12ms", DecimalTokenMatcher, "block=<42> size <1000>, v1.2 a12 12ms"},
+		{"hex", "hash 0xdeadbeef and 0x12z", HexTokenMatcher, "hash <0XDEADBEEF> and 0x12z"},
+		{"bytes", "took 1024 ms for 3 items, read 1024 bytes at 12MiB/s of 1.5 GB", BytesTokenMatcher, "took 1024 ms for 3 items, read <1024 BYTES> at <12MIB/S> of <1.5 GB>"},
+		{"duration", "took 1h30m then 150ms then 5min", DurationTokenMatcher, "took <1H30M> then <150MS> then 5min"},
+		{"combined", "0x10 16 0x20", CombineTokenMatchers(DecimalTokenMatcher, HexTokenMatcher), "<0X10> <16> <0X20>"},
+		{"no match", "nothing here", DecimalTokenMatcher, "nothing here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RewriteTokens(tt.line, tt.matcher, upper))
+		})
+	}
+}
+
+func TestRewriteTokens_KeepsFailedTokens(t *testing.T) {
+	onlyEven := func(element string) (string, string, error) {
+		if (element[len(element)-1]-'0')%2 != 0 {
+			return "", "", fmt.Errorf("odd")
+		}
+
+		return "even", "", nil
+	}
+
+	assert.Equal(t, "even 3 even 5", RewriteTokens("2 3 4 5", DecimalTokenMatcher, onlyEven))
+}
+
+func TestDateTokenMatcher(t *testing.T) {
+	line := "ts=1700000000123 block=42 at 2024-07-23 14:37:10.304 EDT done"
+
+	var tokens []string
+	for _, token := range DateTokenMatcher(testLocation)(line) {
+		tokens = append(tokens, line[token[0]:token[1]])
+	}
+
+	assert.Equal(t, []string{"1700000000123", "2024-07-23 14:37:10.304 EDT"}, tokens)
+}
+
+func Test_epochTokenMatcher(t *testing.T) {
+	line := "s=1700000000 ms=1700000000123 us=1700000000123456 ns=1700000000123456789 frac=1700000000.5 " +
+		"block=123456789012 id=9999999999 old=999999999 digits=17000000001234567"
+
+	var tokens []string
+	for _, token := range epochTokenMatcher(line) {
+		tokens = append(tokens, line[token[0]:token[1]])
+	}
+
+	assert.Equal(t, []string{"1700000000", "1700000000123", "1700000000123456", "1700000000123456789", "1700000000.5"}, tokens)
+}
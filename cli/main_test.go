@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain points the home directory to an empty one so that the user config files
+// (date layouts, tooling config) loaded lazily by the package never leak into the tests.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "cli-test-home")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create test home directory: %s\n", err)
+		os.Exit(1)
+	}
+
+	os.Setenv("HOME", home)
+	os.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
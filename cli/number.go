@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxNumberExponent bounds the exponent of scientific notation to avoid allocating huge numbers
+const maxNumberExponent = 4096
+
+var scientificNumberRegexp = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]*))?(?:[eE]([-+]?[0-9]+))?$`)
+
+var numberWidthFlag numberWidth
+
+// numberWidth is the `-width` flag value, rejecting negative widths when the flags are parsed
+type numberWidth int
+
+func (w *numberWidth) String() string {
+	return strconv.Itoa(int(*w))
+}
+
+func (w *numberWidth) Set(in string) error {
+	value, err := strconv.Atoi(in)
+	if err != nil {
+		return err
+	}
+
+	if value < 0 {
+		return fmt.Errorf("invalid width %d, must be 0 or higher", value)
+	}
+
+	*w = numberWidth(value)
+	return nil
+}
+
+// RegisterNumberFlags registers the shared `-width` flag on the received flag set, see
+// [ParseIntegerToBytesFromFlags].
+func RegisterNumberFlags(flags *flag.FlagSet) {
+	flags.Var(&numberWidthFlag, "width", "Encode integer inputs on exactly this many `bytes`, negative values in two's complement, 0 means the minimal width")
+}
+
+// RegisterNumberDecodeFlags registers the shared `-width` flag like [RegisterNumberFlags] but
+// described for the tools decoding a number from bytes instead of encoding one, see [NumberWidth].
+func RegisterNumberDecodeFlags(flags *flag.FlagSet) {
+	flags.Var(&numberWidthFlag, "width", "Decode the number from exactly this many `bytes` of the input, starting at -offset, 0 means all the remaining bytes")
+}
+
+// ParseNumber parses an integer that can be signed (`-42`, `+42`), prefixed for hexadecimal
+// (`0xff`), octal (`0o17`) or binary (`0b1010`), use `_` digit separators (`1_000_000`) and
+// scientific notation (`1e18`, `1.5e3`) as long as the value is an exact integer.
+func ParseNumber(in string) (*big.Int, error) {
+	digits := strings.TrimSpace(in)
+
+	negative := false
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	base := 10
+	if len(digits) >= 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+
+		if base != 10 {
+			digits = digits[2:]
+
+			// The sign is only accepted before the prefix, big.Int.SetString would accept another one
+			if digits == "" || digits[0] == '-' || digits[0] == '+' {
+				return nil, fmt.Errorf("number %q is invalid, expecting digits after its base prefix", in)
+			}
+		}
+	}
+
+	if strings.Contains(digits, "_") {
+		if strings.HasPrefix(digits, "_") || strings.HasSuffix(digits, "_") || strings.Contains(digits, "__") {
+			return nil, fmt.Errorf("number %q is invalid, digit separators must be between digits", in)
+		}
+
+		digits = strings.ReplaceAll(digits, "_", "")
+	}
+
+	var value *big.Int
+	if base == 10 {
+		var err error
+		if value, err = parseDecimalNumber(digits); err != nil {
+			return nil, fmt.Errorf("number %q is invalid: %w", in, err)
+		}
+	} else {
+		var ok bool
+		if value, ok = new(big.Int).SetString(digits, base); !ok {
+			return nil, fmt.Errorf("number %q is invalid", in)
+		}
+	}
+
+	if negative {
+		value.Neg(value)
+	}
+
+	return value, nil
+}
+
+// parseDecimalNumber parses unsigned decimal digits with an optional fraction and exponent,
+// the fraction must vanish once the exponent is applied
+func parseDecimalNumber(digits string) (*big.Int, error) {
+	match := scientificNumberRegexp.FindStringSubmatch(digits)
+	if match == nil {
+		return nil, fmt.Errorf("expecting decimal digits")
+	}
+
+	exponent := 0
+	if match[3] != "" {
+		var err error
+		if exponent, err = strconv.Atoi(match[3]); err != nil || exponent > maxNumberExponent || exponent < -maxNumberExponent {
+			return nil, fmt.Errorf("exponent %s is out of range", match[3])
+		}
+	}
+
+	value, _ := new(big.Int).SetString(match[1]+match[2], 10)
+	exponent -= len(match[2])
+
+	if exponent >= 0 {
+		return value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)), nil
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exponent)), nil), new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, fmt.Errorf("value is not an integer")
+	}
+
+	return quotient, nil
+}
+
+// NumberToBytes encodes `value` as big-endian bytes on exactly `width` bytes, negative values
+// being encoded in two's complement. When `width` is 0, the minimal number of bytes is used,
+// which is no bytes at all for 0.
+func NumberToBytes(value *big.Int, width int) ([]byte, error) {
+	if width < 0 {
+		return nil, fmt.Errorf("invalid width %d, must be 0 or higher", width)
+	}
+
+	if width == 0 {
+		if value.Sign() >= 0 {
+			return value.Bytes(), nil
+		}
+
+		// Minimal width holding the value as a signed two's complement number
+		width = (new(big.Int).Not(value).BitLen() + 8) / 8
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(width*8))
+	if value.Sign() >= 0 {
+		if value.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("value %s does not fit in %d bytes", value, width)
+		}
+
+		return value.FillBytes(make([]byte, width)), nil
+	}
+
+	if new(big.Int).Neg(value).Cmp(new(big.Int).Rsh(limit, 1)) > 0 {
+		return nil, fmt.Errorf("value %s does not fit in %d bytes", value, width)
+	}
+
+	return new(big.Int).Add(limit, value).FillBytes(make([]byte, width)), nil
+}
+
+// BytesToNumber decodes `bytes` as an integer, the inverse of [NumberToBytes]. The bytes are
+// big-endian unless `littleEndian` is set and an unsigned number unless `signed` is set in which
+// case they are a two's complement number. No bytes at all decodes to 0.
+func BytesToNumber(bytes []byte, signed bool, littleEndian bool) *big.Int {
+	if littleEndian {
+		bytes = slices.Clone(bytes)
+		slices.Reverse(bytes)
+	}
+
+	value := new(big.Int).SetBytes(bytes)
+	if signed && len(bytes) > 0 && bytes[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(bytes)*8)))
+	}
+
+	return value
+}
+
+// NumberWidth returns the `-width` flag value, see [RegisterNumberFlags].
+func NumberWidth() int {
+	return int(numberWidthFlag)
+}
+
+// ParseIntegerToBytesFromFlags is [ParseNumber] followed by [NumberToBytes] using the `-width` flag value.
+func ParseIntegerToBytesFromFlags(in string) ([]byte, error) {
+	value, err := ParseNumber(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return NumberToBytes(value, NumberWidth())
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/hex"
+	"flag"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{"42", "42", ""},
+		{"-42", "-42", ""},
+		{"+42", "42", ""},
+		{"0042", "42", ""},
+		{"0xff", "255", ""},
+		{"-0XFF", "-255", ""},
+		{"0o17", "15", ""},
+		{"0b1010", "10", ""},
+		{"1_000_000", "1000000", ""},
+		{"0xdead_beef", "3735928559", ""},
+		{"1e18", "1000000000000000000", ""},
+		{"1.5e3", "1500", ""},
+		{"2.50E+1", "25", ""},
+		{"1200e-2", "12", ""},
+		{"123456789012345678901234567890", "123456789012345678901234567890", ""},
+		{"1.5", "", "value is not an integer"},
+		{"1e-1", "", "value is not an integer"},
+		{"1e99999", "", "exponent 99999 is out of range"},
+		{"_1", "", "digit separators must be between digits"},
+		{"1__0", "", "digit separators must be between digits"},
+		{"0xfg", "", `number "0xfg" is invalid`},
+		{"0x-5", "", `number "0x-5" is invalid, expecting digits after its base prefix`},
+		{"-0x-5", "", `number "-0x-5" is invalid, expecting digits after its base prefix`},
+		{"0b+1", "", `number "0b+1" is invalid, expecting digits after its base prefix`},
+		{"0x", "", `number "0x" is invalid, expecting digits after its base prefix`},
+		{"-0o", "", `number "-0o" is invalid, expecting digits after its base prefix`},
+		{"0", "0", ""},
+		{"abc", "", "expecting decimal digits"},
+		{"", "", "expecting decimal digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseNumber(tt.in)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestNumberToBytes(t *testing.T) {
+	tests := []struct {
+		value   int64
+		width   int
+		want    string
+		wantErr string
+	}{
+		{0, 0, "", ""},
+		{255, 0, "ff", ""},
+		{256, 0, "0100", ""},
+		{-1, 0, "ff", ""},
+		{-128, 0, "80", ""},
+		{-129, 0, "ff7f", ""},
+		{-42, 0, "d6", ""},
+		{42, 4, "0000002a", ""},
+		{-42, 4, "ffffffd6", ""},
+		{255, 1, "ff", ""},
+		{256, 1, "", "value 256 does not fit in 1 bytes"},
+		{-129, 1, "", "value -129 does not fit in 1 bytes"},
+		{1, -1, "", "invalid width -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(big.NewInt(tt.value).String(), func(t *testing.T) {
+			got, err := NumberToBytes(big.NewInt(tt.value), tt.width)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestBytesToNumber(t *testing.T) {
+	tests := []struct {
+		in           string
+		signed       bool
+		littleEndian bool
+		want         string
+	}{
+		{"", false, false, "0"},
+		{"", true, false, "0"},
+		{"ff", false, false, "255"},
+		{"ff", true, false, "-1"},
+		{"7f", true, false, "127"},
+		{"80", true, false, "-128"},
+		{"ffffffd6", true, false, "-42"},
+		{"d6ffffff", true, true, "-42"},
+		{"0100", false, false, "256"},
+		{"0100", false, true, "1"},
+		{"00000000000000000000000000000080", true, true, "-170141183460469231731687303715884105728"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, BytesToNumber(in, tt.signed, tt.littleEndian).String())
+			assert.Equal(t, tt.in, hex.EncodeToString(in), "input must not be modified")
+		})
+	}
+}
+
+func TestRegisterNumberFlags(t *testing.T) {
+	t.Cleanup(func() { numberWidthFlag = 0 })
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	RegisterNumberFlags(flags)
+
+	require.NoError(t, flags.Parse([]string{"-width", "4"}))
+	assert.Equal(t, 4, NumberWidth())
+
+	assert.EqualError(t, flags.Parse([]string{"-width", "-3"}), `invalid value "-3" for flag -width: invalid width -3, must be 0 or higher`)
+}
@@ -0,0 +1,317 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// OutputFormat defines how an [Emitter] renders each converted element.
+type OutputFormat string
+
+const (
+	// OutputFormatText prints the bare output value, one per line, this is the default.
+	OutputFormatText OutputFormat = "text"
+
+	// OutputFormatJSON prints a single JSON array containing one [Result] per element.
+	OutputFormatJSON OutputFormat = "json"
+
+	// OutputFormatJSONLines prints one JSON encoded [Result] per line.
+	OutputFormatJSONLines OutputFormat = "jsonl"
+
+	// OutputFormatTSV prints one tab separated line per element with columns
+	// input, output, error and kind in this order.
+	OutputFormatTSV OutputFormat = "tsv"
+)
+
+var outputFormats = []OutputFormat{OutputFormatText, OutputFormatJSON, OutputFormatJSONLines, OutputFormatTSV}
+
+func ParseOutputFormat(in string) (OutputFormat, error) {
+	for _, format := range outputFormats {
+		if strings.EqualFold(in, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown output format %q, valid values are %s", in, joinOutputFormats(", "))
+}
+
+func joinOutputFormats(sep string) string {
+	values := make([]string, len(outputFormats))
+	for i, format := range outputFormats {
+		values[i] = string(format)
+	}
+
+	return strings.Join(values, sep)
+}
+
+var outputFormat = string(OutputFormatText)
+
+// RegisterOutputFlags registers the shared `-output` flag on the received flag set. The
+// value is used by [NewEmitter] and [ConvertArguments].
+func RegisterOutputFlags(flags FlagSet) {
+	flags.StringVar(&outputFormat, "output", string(OutputFormatText), fmt.Sprintf("Output format, one of %s, machine-readable formats emit the input, output, error and kind of each element", joinOutputFormats(", ")))
+}
+
+// RegisterConverterFlags registers all the flags shared by converter tools, see
+// [RegisterFramingFlags], [RegisterOutputFlags] and [RegisterErrorPolicyFlags].
+func RegisterConverterFlags(flags FlagSet) {
+	RegisterFramingFlags(flags)
+	RegisterOutputFlags(flags)
+	RegisterErrorPolicyFlags(flags)
+}
+
+// Result is the outcome of converting a single input element.
+type Result struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	Error  error  `json:"error"`
+
+	// Kind is how the input was interpreted (e.g. `hex`, `base64`, `timestamp`), empty
+	// when the converter has a single interpretation.
+	Kind string `json:"kind"`
+
+	// Explain are the lines describing how the output was obtained, if requested. They are
+	// printed indented below the output in text mode, emitted as the `explain` field by the
+	// JSON formats and left out of the TSV format.
+	Explain []string `json:"explain,omitempty"`
+
+	// Line is the input line where the element starts, see [ElementLine], reported with the
+	// error instead of the element index when set. It's not emitted.
+	Line int `json:"-"`
+
+	// Score is how plausible the interpretation is, set by converters ranking several
+	// interpretations of the same input. It is emitted as the `score` field by the JSON
+	// formats and left out of the text and TSV formats.
+	Score *int `json:"score,omitempty"`
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	var output, errorMessage, kind *string
+	if r.Error != nil {
+		message := r.Error.Error()
+		errorMessage = &message
+	} else {
+		output = &r.Output
+	}
+
+	if r.Kind != "" {
+		kind = &r.Kind
+	}
+
+	return json.Marshal(struct {
+		Input   string   `json:"input"`
+		Output  *string  `json:"output"`
+		Error   *string  `json:"error"`
+		Kind    *string  `json:"kind"`
+		Explain []string `json:"explain,omitempty"`
+		Score   *int     `json:"score,omitempty"`
+	}{r.Input, output, errorMessage, kind, r.Explain, r.Score})
+}
+
+// Converter converts a single input element returning its output as well as the kind of
+// interpretation that was performed. An error is returned if the element cannot be converted.
+type Converter func(element string) (output string, kind string, err error)
+
+// ResultConverter is like [Converter] but builds the whole [Result] of the element, for
+// converters reporting more than the output and kind (e.g. [Result.Explain]).
+type ResultConverter func(element string) Result
+
+// Results adapts the converter into a [ResultConverter].
+func (c Converter) Results() ResultConverter {
+	return func(element string) Result {
+		output, kind, err := c(element)
+
+		return Result{Input: element, Output: output, Kind: kind, Error: err}
+	}
+}
+
+// Emitter renders [Result] according to an [OutputFormat], results carrying an error
+// are handled through an [ErrorHandler].
+//
+// In text mode, the [ErrorPolicy] decides if the element is dropped or printed unchanged. The
+// machine-readable formats always emit the result with its error, the policy only decides
+// if processing continues.
+type Emitter struct {
+	writer   io.Writer
+	format   OutputFormat
+	errors   *ErrorHandler
+	count    int
+	rendered int
+}
+
+// NewEmitter creates an [Emitter] writing to standard output in the format defined
+// by the `-output` flag and handling errors according to the `-on-error` flag, see
+// [RegisterOutputFlags] and [RegisterErrorPolicyFlags].
+func NewEmitter() *Emitter {
+	format, err := ParseOutputFormat(outputFormat)
+	NoError(err, "invalid -output flag")
+
+	return NewEmitterTo(os.Stdout, format, NewErrorHandler())
+}
+
+func NewEmitterTo(writer io.Writer, format OutputFormat, errors *ErrorHandler) *Emitter {
+	emitter := &Emitter{writer: writer, format: format, errors: errors}
+	errors.onFailFast = emitter.Close
+
+	return emitter
+}
+
+func (e *Emitter) Errors() *ErrorHandler {
+	return e.errors
+}
+
+func (e *Emitter) IsText() bool {
+	return e.format == OutputFormatText
+}
+
+// Emit renders the received result, errors are reported through the [ErrorHandler]
+// with the position of the result in the stream as the element index.
+func (e *Emitter) Emit(result Result) {
+	e.count++
+	e.emit(result)
+}
+
+// EmitAll renders several results of the same element, like the interpretations of a value,
+// counting them as a single element in the index reported with errors.
+func (e *Emitter) EmitAll(results []Result) {
+	e.count++
+	for _, result := range results {
+		e.emit(result)
+	}
+}
+
+func (e *Emitter) emit(result Result) {
+	if result.Error != nil {
+		if e.format != OutputFormatText {
+			e.render(result)
+		}
+
+		e.errors.Handle(ElementPosition{Index: e.count, Line: result.Line}, result.Input, result.Error)
+
+		if e.format == OutputFormatText && e.errors.Policy() == ErrorPolicyPassthrough {
+			fmt.Fprintln(e.writer, result.Input)
+		}
+
+		return
+	}
+
+	e.render(result)
+}
+
+func (e *Emitter) render(result Result) {
+	defer func() { e.rendered++ }()
+
+	switch e.format {
+	case OutputFormatText:
+		fmt.Fprintln(e.writer, result.Output)
+		for _, line := range result.Explain {
+			fmt.Fprintln(e.writer, "  "+line)
+		}
+
+	case OutputFormatJSONLines:
+		e.writeJSON(result)
+		fmt.Fprintln(e.writer)
+
+	case OutputFormatJSON:
+		if e.rendered == 0 {
+			fmt.Fprintln(e.writer, "[")
+		} else {
+			fmt.Fprintln(e.writer, ",")
+		}
+
+		fmt.Fprint(e.writer, "  ")
+		e.writeJSON(result)
+
+	case OutputFormatTSV:
+		errorMessage := ""
+		if result.Error != nil {
+			errorMessage = result.Error.Error()
+		}
+
+		fmt.Fprintln(e.writer, strings.Join([]string{
+			tsvEscape(result.Input),
+			tsvEscape(result.Output),
+			tsvEscape(errorMessage),
+			tsvEscape(result.Kind),
+		}, "\t"))
+	}
+}
+
+// Close terminates the output, it must be called once all results have been emitted.
+func (e *Emitter) Close() {
+	if e.format != OutputFormatJSON {
+		return
+	}
+
+	if e.rendered == 0 {
+		fmt.Fprintln(e.writer, "[]")
+		return
+	}
+
+	fmt.Fprintln(e.writer)
+	fmt.Fprintln(e.writer, "]")
+}
+
+func (e *Emitter) writeJSON(result Result) {
+	out, err := json.Marshal(result)
+	NoError(err, "unable to marshal result")
+
+	e.writer.Write(out)
+}
+
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+func tsvEscape(in string) string {
+	return tsvEscaper.Replace(in)
+}
+
+// ConvertArguments runs `converter` over each element of `scanner` and emits the results
+// through an [Emitter] configured from flags, see [NewEmitter]. It returns the number of
+// elements processed.
+//
+// If some elements failed, the process exits once all elements have been processed, see
+// [ErrorHandler.ExitOnFailures].
+func ConvertArguments(scanner ArgumentScanner, converter Converter) int {
+	return ConvertArgumentsToResults(scanner, converter.Results(), nil)
+}
+
+// ConvertArgumentsToResults is like [ConvertArguments] but with a [ResultConverter], it also
+// emits the result of `fallback`, when non-nil, if `scanner` has no element at all.
+func ConvertArgumentsToResults(scanner ArgumentScanner, converter ResultConverter, fallback func() Result) int {
+	emitter := NewEmitter()
+
+	count := emitResults(emitter, scanner, converter, fallback)
+	emitter.Errors().ExitOnFailures(count)
+
+	return count
+}
+
+// emitResults emits the result of each element of `scanner` and closes `emitter`, returning
+// the number of elements processed
+func emitResults(emitter *Emitter, scanner ArgumentScanner, converter ResultConverter, fallback func() Result) int {
+	count := 0
+	for element, ok, err := ScanElement(scanner); ok; element, ok, err = ScanElement(scanner) {
+		if err != nil {
+			emitter.Emit(Result{Input: element, Error: err, Line: ElementLine(scanner)})
+			count++
+			continue
+		}
+
+		result := converter(element)
+		result.Line = ElementLine(scanner)
+		emitter.Emit(result)
+
+		count++
+	}
+
+	if count == 0 && fallback != nil {
+		emitter.Emit(fallback())
+	}
+
+	emitter.Close()
+
+	return count
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmitter(t *testing.T) {
+	score := 50
+	results := []Result{
+		{Input: "0x0a", Output: "10", Kind: "hex"},
+		{Input: "x\ty", Error: errors.New("invalid value")},
+		{Input: "1", Output: "one", Explain: []string{"parsed: integer"}},
+		{Input: "ff", Output: "255", Kind: "hex", Score: &score},
+	}
+
+	tests := []struct {
+		format  OutputFormat
+		results []Result
+		want    string
+	}{
+		{
+			OutputFormatText,
+			results,
+			"10\none\n  parsed: integer\n255\n",
+		},
+		{
+			OutputFormatJSONLines,
+			results,
+			`{"input":"0x0a","output":"10","error":null,"kind":"hex"}` + "\n" +
+				`{"input":"x\ty","output":null,"error":"invalid value","kind":null}` + "\n" +
+				`{"input":"1","output":"one","error":null,"kind":null,"explain":["parsed: integer"]}` + "\n" +
+				`{"input":"ff","output":"255","error":null,"kind":"hex","score":50}` + "\n",
+		},
+		{
+			OutputFormatJSON,
+			results,
+			"[\n" +
+				`  {"input":"0x0a","output":"10","error":null,"kind":"hex"},` + "\n" +
+				`  {"input":"x\ty","output":null,"error":"invalid value","kind":null},` + "\n" +
+				`  {"input":"1","output":"one","error":null,"kind":null,"explain":["parsed: integer"]},` + "\n" +
+				`  {"input":"ff","output":"255","error":null,"kind":"hex","score":50}` + "\n" +
+				"]\n",
+		},
+		{
+			OutputFormatJSON,
+			nil,
+			"[]\n",
+		},
+		{
+			OutputFormatTSV,
+			results,
+			"0x0a\t10\t\thex\n" +
+				"x\\ty\t\tinvalid value\t\n" +
+				"1\tone\t\t\n" +
+				"ff\t255\t\thex\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+
+			emitter := NewEmitterTo(buffer, tt.format, NewErrorHandlerTo(io.Discard, ErrorPolicySkip))
+			for _, result := range tt.results {
+				emitter.Emit(result)
+			}
+			emitter.Close()
+
+			assert.Equal(t, tt.want, buffer.String())
+		})
+	}
+}
+
+func TestEmitter_Passthrough(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	errorsBuffer := bytes.NewBuffer(nil)
+
+	errorHandler := NewErrorHandlerTo(errorsBuffer, ErrorPolicyPassthrough)
+	emitter := NewEmitterTo(buffer, OutputFormatText, errorHandler)
+	emitter.Emit(Result{Input: "a", Output: "A"})
+	emitter.Emit(Result{Input: "b", Error: errors.New("invalid value")})
+	emitter.Emit(Result{Input: "c", Output: "C"})
+	emitter.Close()
+
+	assert.Equal(t, "A\nb\nC\n", buffer.String())
+	assert.Equal(t, "element 2: \"b\": invalid value\n", errorsBuffer.String())
+	assert.Equal(t, 1, errorHandler.Failures())
+}
+
+func TestEmitter_EmitAll(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	errorsBuffer := bytes.NewBuffer(nil)
+
+	emitter := NewEmitterTo(buffer, OutputFormatJSONLines, NewErrorHandlerTo(errorsBuffer, ErrorPolicySkip))
+	emitter.EmitAll([]Result{{Input: "a", Output: "A", Kind: "upper"}, {Input: "a", Output: "a", Kind: "lower"}})
+	emitter.Emit(Result{Input: "b", Error: errors.New("invalid value")})
+	emitter.Close()
+
+	assert.Equal(t, `{"input":"a","output":"A","error":null,"kind":"upper"}`+"\n"+
+		`{"input":"a","output":"a","error":null,"kind":"lower"}`+"\n"+
+		`{"input":"b","output":null,"error":"invalid value","kind":null}`+"\n", buffer.String())
+	assert.Equal(t, "element 2: \"b\": invalid value\n", errorsBuffer.String())
+}
+
+func TestEmitter_Line(t *testing.T) {
+	errorsBuffer := bytes.NewBuffer(nil)
+
+	emitter := NewEmitterTo(io.Discard, OutputFormatText, NewErrorHandlerTo(errorsBuffer, ErrorPolicySkip))
+	emitter.Emit(Result{Input: "a", Output: "A", Line: 2})
+	emitter.Emit(Result{Input: "b", Error: errors.New("invalid value"), Line: 5})
+	emitter.Emit(Result{Input: "c", Error: errors.New("invalid value")})
+	emitter.Close()
+
+	assert.Equal(t, "line 5: \"b\": invalid value\nelement 3: \"c\": invalid value\n", errorsBuffer.String())
+}
@@ -0,0 +1,417 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuantityKind is the kind of value a [Quantity] holds.
+type QuantityKind string
+
+const (
+	QuantityKindNumber   QuantityKind = "number"
+	QuantityKindBytes    QuantityKind = "bytes"
+	QuantityKindDuration QuantityKind = "duration"
+	QuantityKindRate     QuantityKind = "rate"
+)
+
+// Quantity is a number with an optional unit as parsed by [ParseQuantity] like `12`,
+// `1.5 GiB`, `1d 2h`, `12 MiB/s` or `400 msg/s`.
+type Quantity struct {
+	Kind QuantityKind
+
+	// Value is expressed in the base unit of the kind: bytes, nanoseconds, or per second for rates.
+	Value float64
+
+	// Binary is true when the bytes unit was IEC (base 1024, e.g. `MiB`), false when it
+	// was SI (base 1000, e.g. `MB`), only meaningful for bytes and bytes rates.
+	Binary bool
+
+	// RateUnit is the unit of a rate numerator, `B` for bytes rates, the unit as written for
+	// the others (e.g. `msg`) and empty if the rate had no unit (e.g. `400/s`).
+	RateUnit string
+}
+
+// ByteUnit is a bytes unit like `MiB` or `MB`.
+type ByteUnit struct {
+	Name       string
+	Multiplier float64
+	Binary     bool
+}
+
+// BinaryByteUnits are the IEC bytes units, largest first.
+var BinaryByteUnits = []ByteUnit{
+	{"EiB", 1 << 60, true},
+	{"PiB", 1 << 50, true},
+	{"TiB", 1 << 40, true},
+	{"GiB", 1 << 30, true},
+	{"MiB", 1 << 20, true},
+	{"KiB", 1 << 10, true},
+	{"B", 1, true},
+}
+
+// DecimalByteUnits are the SI bytes units, largest first.
+var DecimalByteUnits = []ByteUnit{
+	{"EB", 1e18, false},
+	{"PB", 1e15, false},
+	{"TB", 1e12, false},
+	{"GB", 1e9, false},
+	{"MB", 1e6, false},
+	{"KB", 1e3, false},
+	{"B", 1, false},
+}
+
+// LookupByteUnit finds the bytes unit named `name` case insensitively, `B`, `byte` and `bytes`
+// are all the bytes unit itself.
+func LookupByteUnit(name string) (ByteUnit, bool) {
+	switch strings.ToLower(name) {
+	case "b", "byte", "bytes":
+		return BinaryByteUnits[len(BinaryByteUnits)-1], true
+	}
+
+	for _, units := range [][]ByteUnit{BinaryByteUnits, DecimalByteUnits} {
+		for _, unit := range units {
+			if strings.EqualFold(unit.Name, name) {
+				return unit, true
+			}
+		}
+	}
+
+	return ByteUnit{}, false
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// rateDenominators are the accepted units after the `/` of a rate
+var rateDenominators = map[string]time.Duration{
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"sec": time.Second,
+	"m":   time.Minute,
+	"min": time.Minute,
+	"h":   time.Hour,
+	"d":   24 * time.Hour,
+}
+
+const quantityNumberExpression = `[-+]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][-+]?[0-9]+)?`
+
+var bytesQuantityRegexp = regexp.MustCompile(`^(` + quantityNumberExpression + `)\s*([A-Za-z]+)$`)
+var rateNumeratorRegexp = regexp.MustCompile(`^(` + quantityNumberExpression + `)\s*([A-Za-zµ]*)$`)
+
+// The alternation is ordered so that `ms` is tried before `m`
+const durationComponentExpression = `([0-9]+(?:\.[0-9]+)?|\.[0-9]+)\s*(ns|us|µs|μs|ms|s|m|h|d|w)`
+
+var durationQuantityRegexp = regexp.MustCompile(`^([-+]?)\s*((?:` + durationComponentExpression + `\s*)+)$`)
+var durationComponentRegexp = regexp.MustCompile(durationComponentExpression)
+
+// ParseQuantity parses a plain number, bytes (`1.5 GiB`, `12MB`), a duration (`1h30m`,
+// `2d 4h`, `1w`) or a rate (`12 MiB/s`, `400 msg/s`, `30/min`). Bytes units are case
+// insensitive, rates are normalized to per second.
+func ParseQuantity(element string) (Quantity, error) {
+	element = strings.TrimSpace(element)
+	if element == "" {
+		return Quantity{}, fmt.Errorf("empty quantity")
+	}
+
+	if index := strings.LastIndex(element, "/"); index != -1 {
+		return parseRateQuantity(element[:index], element[index+1:])
+	}
+
+	if match := bytesQuantityRegexp.FindStringSubmatch(element); match != nil {
+		if unit, found := LookupByteUnit(match[2]); found {
+			value, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return Quantity{}, fmt.Errorf("invalid number in bytes value %q: %w", element, err)
+			}
+
+			return Quantity{Kind: QuantityKindBytes, Value: value * unit.Multiplier, Binary: unit.Binary}, nil
+		}
+	}
+
+	if durationQuantityRegexp.MatchString(element) {
+		duration, err := ParseDuration(element)
+		if err != nil {
+			return Quantity{}, err
+		}
+
+		return Quantity{Kind: QuantityKindDuration, Value: float64(duration)}, nil
+	}
+
+	value, err := strconv.ParseFloat(element, 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid quantity %q, expecting a number, bytes, a duration or a rate", element)
+	}
+
+	return Quantity{Kind: QuantityKindNumber, Value: value}, nil
+}
+
+func parseRateQuantity(numerator, denominator string) (Quantity, error) {
+	per, found := rateDenominators[strings.TrimSpace(denominator)]
+	if !found {
+		return Quantity{}, fmt.Errorf("invalid rate unit %q, expecting one of ms, s, sec, m, min, h or d", denominator)
+	}
+
+	match := rateNumeratorRegexp.FindStringSubmatch(strings.TrimSpace(numerator))
+	if match == nil {
+		return Quantity{}, fmt.Errorf("invalid rate value %q", numerator)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid number in rate value %q: %w", numerator, err)
+	}
+
+	out := Quantity{Kind: QuantityKindRate, RateUnit: match[2]}
+	if unit, found := LookupByteUnit(match[2]); found {
+		value *= unit.Multiplier
+		out.RateUnit = "B"
+		out.Binary = unit.Binary
+	}
+
+	out.Value = value / per.Seconds()
+	return out, nil
+}
+
+// ParseDuration is like [time.ParseDuration] but also accepts days (`d`, 24h) and weeks
+// (`w`, 7d) as well as spaces between components like `1d 2h 30m`.
+func ParseDuration(element string) (time.Duration, error) {
+	match := durationQuantityRegexp.FindStringSubmatch(strings.TrimSpace(element))
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", element)
+	}
+
+	total := 0.0
+	for _, component := range durationComponentRegexp.FindAllStringSubmatch(match[2], -1) {
+		value, err := strconv.ParseFloat(component[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", element, err)
+		}
+
+		total += value * float64(durationUnits[component[2]])
+	}
+
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid duration %q: overflows maximum duration", element)
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+
+	return time.Duration(math.Round(total)), nil
+}
+
+// String formats the quantity in a human readable way, see [FormatBytes], [FormatDuration]
+// and [FormatNumber].
+func (q Quantity) String() string {
+	switch q.Kind {
+	case QuantityKindBytes:
+		return FormatBytes(q.Value, q.Binary)
+	case QuantityKindDuration:
+		return FormatDuration(time.Duration(q.Value))
+	case QuantityKindRate:
+		if q.RateUnit == "B" {
+			return FormatBytes(q.Value, q.Binary) + "/s"
+		}
+
+		if q.RateUnit == "" {
+			return FormatNumber(q.Value) + "/s"
+		}
+
+		return FormatNumber(q.Value) + " " + q.RateUnit + "/s"
+	}
+
+	return FormatNumber(q.Value)
+}
+
+// FormatBytes formats `value` using the largest bytes unit up to PiB (or PB), IEC ones (`MiB`) if `binary`
+// is true, SI ones (`MB`) otherwise, e.g. `95.37 MiB`.
+func FormatBytes(value float64, binary bool) string {
+	units := formatByteUnits(binary)
+	for _, unit := range units[:len(units)-1] {
+		if math.Abs(value) >= unit.Multiplier {
+			return fmt.Sprintf("%.2f %s", value/unit.Multiplier, unit.Name)
+		}
+	}
+
+	return fmt.Sprintf("%s B", FormatNumber(value))
+}
+
+// FormatBigBytes is like [FormatBytes] but for an integer bytes count of any size, the
+// value is divided exactly so huge counts are not rounded to the nearest float64 first.
+func FormatBigBytes(value *big.Int, binary bool) string {
+	units := formatByteUnits(binary)
+	magnitude := new(big.Int).Abs(value)
+
+	for _, unit := range units[:len(units)-1] {
+		multiplier := new(big.Int).SetUint64(uint64(unit.Multiplier))
+		if magnitude.Cmp(multiplier) >= 0 {
+			return fmt.Sprintf("%s %s", new(big.Rat).SetFrac(value, multiplier).FloatString(2), unit.Name)
+		}
+	}
+
+	return fmt.Sprintf("%s B", value)
+}
+
+// formatByteUnits returns the units used when formatting bytes, largest first. The exabyte
+// units are only accepted when parsing, PiB (or PB) is the largest unit formatted.
+func formatByteUnits(binary bool) []ByteUnit {
+	if binary {
+		return BinaryByteUnits[1:]
+	}
+
+	return DecimalByteUnits[1:]
+}
+
+// FormatNumber formats `value` without decimals when it's an integer and with 3 decimals otherwise.
+func FormatNumber(value float64) string {
+	if value == math.Trunc(value) && math.Abs(value) < 1e18 {
+		return strconv.FormatInt(int64(value), 10)
+	}
+
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
+
+// FormatDuration is like [time.Duration.String] but with spaces between components for
+// easier readability and days support, days being approximated as 24h, e.g. `1d 2h 0m 5s`.
+func FormatDuration(d time.Duration) string {
+	// Largest time is 106751d 23h 47m 16.854775807s
+	var buf [40]byte
+	w := len(buf)
+
+	u := uint64(d)
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+
+	if u < uint64(time.Second) {
+		// Special case: if duration is smaller than a second,
+		// use smaller units, like 1.2ms
+		var prec int
+		w--
+		buf[w] = 's'
+		w--
+		switch {
+		case u == 0:
+			return "0s"
+		case u < uint64(time.Microsecond):
+			// print nanoseconds
+			prec = 0
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			// print microseconds
+			prec = 3
+			// U+00B5 'µ' micro sign == 0xC2 0xB5
+			w-- // Need room for two bytes.
+			copy(buf[w:], "µ")
+		default:
+			// print milliseconds
+			prec = 6
+			buf[w] = 'm'
+		}
+		w, u = fmtFrac(buf[:w], u, prec)
+		w = fmtInt(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+
+		w, u = fmtFrac(buf[:w], u, 9)
+
+		// u is now integer seconds
+		w = fmtInt(buf[:w], u%60)
+		u /= 60
+
+		// u is now integer minutes
+		if u > 0 {
+			w--
+			w--
+			copy(buf[w:], "m ")
+			w = fmtInt(buf[:w], u%60)
+			u /= 60
+
+			// u is now integer hours
+			// Continue at hours (contrary to original code) because we accept the approximation that all days are 24h
+			if u > 0 {
+				w--
+				w--
+				copy(buf[w:], "h ")
+				w = fmtInt(buf[:w], u%24)
+				u /= 24
+
+				// u is now integer days
+				// Stop at days, it's enough
+				if u > 0 {
+					w--
+					w--
+					copy(buf[w:], "d ")
+					w = fmtInt(buf[:w], u)
+				}
+			}
+		}
+	}
+
+	if neg {
+		w--
+		buf[w] = '-'
+	}
+
+	return string(buf[w:])
+}
+
+// fmtFrac formats the fraction of v/10**prec (e.g., ".12345") into the
+// tail of buf, omitting trailing zeros. It omits the decimal
+// point too when the fraction is 0. It returns the index where the
+// output bytes begin and the value v/10**prec.
+func fmtFrac(buf []byte, v uint64, prec int) (nw int, nv uint64) {
+	// Omit trailing zeros up to and including decimal point.
+	w := len(buf)
+	print := false
+	for i := 0; i < prec; i++ {
+		digit := v % 10
+		print = print || digit != 0
+		if print {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if print {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// fmtInt formats v into the tail of buf.
+// It returns the index where the output begins.
+func fmtInt(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+	} else {
+		for v > 0 {
+			w--
+			buf[w] = byte(v%10) + '0'
+			v /= 10
+		}
+	}
+	return w
+}
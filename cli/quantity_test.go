@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		element    string
+		want       Quantity
+		wantString string
+		wantErr    string
+	}{
+		{"12", Quantity{Kind: QuantityKindNumber, Value: 12}, "12", ""},
+		{"-1.5", Quantity{Kind: QuantityKindNumber, Value: -1.5}, "-1.500", ""},
+		{"1e3", Quantity{Kind: QuantityKindNumber, Value: 1000}, "1000", ""},
+		{"1.5 GiB", Quantity{Kind: QuantityKindBytes, Value: 1.5 * (1 << 30), Binary: true}, "1.50 GiB", ""},
+		{"12mb", Quantity{Kind: QuantityKindBytes, Value: 12e6}, "12.00 MB", ""},
+		{"512 bytes", Quantity{Kind: QuantityKindBytes, Value: 512, Binary: true}, "512 B", ""},
+		{"1h30m", Quantity{Kind: QuantityKindDuration, Value: float64(90 * time.Minute)}, "1h 30m 0s", ""},
+		{"2d 4h", Quantity{Kind: QuantityKindDuration, Value: float64(52 * time.Hour)}, "2d 4h 0m 0s", ""},
+		{"1w", Quantity{Kind: QuantityKindDuration, Value: float64(7 * 24 * time.Hour)}, "7d 0h 0m 0s", ""},
+		{"-250ms", Quantity{Kind: QuantityKindDuration, Value: float64(-250 * time.Millisecond)}, "-250ms", ""},
+		{"12 MiB/s", Quantity{Kind: QuantityKindRate, Value: 12 * (1 << 20), Binary: true, RateUnit: "B"}, "12.00 MiB/s", ""},
+		{"400 msg/s", Quantity{Kind: QuantityKindRate, Value: 400, RateUnit: "msg"}, "400 msg/s", ""},
+		{"30/min", Quantity{Kind: QuantityKindRate, Value: 0.5}, "0.500/s", ""},
+		{"12 apples", Quantity{}, "", `invalid quantity "12 apples"`},
+		{"12/fortnight", Quantity{}, "", `invalid rate unit "fortnight"`},
+		{"", Quantity{}, "", "empty quantity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			got, err := ParseQuantity(tt.element)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantString, got.String())
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		element string
+		want    time.Duration
+		wantErr string
+	}{
+		{"10ms", 10 * time.Millisecond, ""},
+		{"1.5d", 36 * time.Hour, ""},
+		{"1d 2h 3m 4s", 26*time.Hour + 3*time.Minute + 4*time.Second, ""},
+		{"3 µs", 3 * time.Microsecond, ""},
+		{"- 1h", -time.Hour, ""},
+		{"1y", 0, `invalid duration "1y"`},
+		{"100000w", 0, "overflows maximum duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			got, err := ParseDuration(tt.element)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "95.37 MiB", FormatBytes(100000000, true))
+	assert.Equal(t, "100.00 MB", FormatBytes(100000000, false))
+	assert.Equal(t, "-1.00 KiB", FormatBytes(-1024, true))
+	assert.Equal(t, "0 B", FormatBytes(0, false))
+	assert.Equal(t, "2000.00 PB", FormatBytes(2e18, false))
+}
+
+func TestFormatBigBytes(t *testing.T) {
+	value, _ := new(big.Int).SetString("123456789012345678901234", 10)
+
+	assert.Equal(t, "109651655.77 PiB", FormatBigBytes(value, true))
+	assert.Equal(t, "123456789.01 PB", FormatBigBytes(value, false))
+	assert.Equal(t, "1.00 KiB", FormatBigBytes(big.NewInt(1024), true))
+	assert.Equal(t, "-1.50 KB", FormatBigBytes(big.NewInt(-1500), false))
+	assert.Equal(t, "512 B", FormatBigBytes(big.NewInt(512), true))
+}
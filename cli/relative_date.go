@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeOffset is a signed amount of time expressed both in calendar units (years, months
+// and days, applied through [time.Time.AddDate] so they are DST aware) and in exact
+// duration (hours and below, as well as fractional calendar units).
+type relativeOffset struct {
+	months   int
+	days     int
+	duration time.Duration
+}
+
+func (o relativeOffset) negate() relativeOffset {
+	return relativeOffset{-o.months, -o.days, -o.duration}
+}
+
+func (o relativeOffset) applyTo(in time.Time) time.Time {
+	return in.AddDate(0, o.months, o.days).Add(o.duration)
+}
+
+func (o relativeOffset) String() string {
+	sign := "+"
+	if o.months < 0 || o.days < 0 || o.duration < 0 {
+		sign = "-"
+		o = o.negate()
+	}
+
+	out := sign
+	if o.months != 0 {
+		out += fmt.Sprintf("%dmo", o.months)
+	}
+
+	if o.days != 0 {
+		out += fmt.Sprintf("%dd", o.days)
+	}
+
+	if o.duration != 0 || (o.months == 0 && o.days == 0) {
+		out += o.duration.String()
+	}
+
+	return out
+}
+
+type relativeUnit struct {
+	months   int
+	days     int
+	duration time.Duration
+}
+
+var relativeUnits = map[string]relativeUnit{}
+
+func init() {
+	register := func(unit relativeUnit, names ...string) {
+		for _, name := range names {
+			relativeUnits[name] = unit
+		}
+	}
+
+	register(relativeUnit{duration: time.Nanosecond}, "ns", "nanosecond", "nanoseconds")
+	register(relativeUnit{duration: time.Microsecond}, "us", "µs", "microsecond", "microseconds")
+	register(relativeUnit{duration: time.Millisecond}, "ms", "millisecond", "milliseconds")
+	register(relativeUnit{duration: time.Second}, "s", "sec", "secs", "second", "seconds")
+	register(relativeUnit{duration: time.Minute}, "m", "min", "mins", "minute", "minutes")
+	register(relativeUnit{duration: time.Hour}, "h", "hr", "hrs", "hour", "hours")
+	register(relativeUnit{days: 1}, "d", "day", "days")
+	register(relativeUnit{days: 7}, "w", "week", "weeks")
+	register(relativeUnit{months: 1}, "mo", "month", "months")
+	register(relativeUnit{months: 12}, "y", "year", "years")
+}
+
+var relativeAmountRegexp = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-zµ]+)`)
+
+// parseRelativeOffset parses an unsigned amount of time like `2h`, `1d12h`, `3 days` or
+// `2 hours 30 minutes`. Fractional calendar units are converted to an exact duration
+// using 24h days and 30 days months.
+func parseRelativeOffset(in string) (out relativeOffset, ok bool) {
+	in = strings.TrimSpace(strings.ToLower(in))
+	if in == "" {
+		return out, false
+	}
+
+	matches := relativeAmountRegexp.FindAllStringSubmatchIndex(in, -1)
+	if len(matches) == 0 {
+		return out, false
+	}
+
+	end := 0
+	for _, match := range matches {
+		// Everything between two amounts must be blank so we consumed the full input
+		if strings.TrimSpace(in[end:match[0]]) != "" {
+			return out, false
+		}
+		end = match[1]
+
+		unit, found := relativeUnits[in[match[4]:match[5]]]
+		if !found {
+			return out, false
+		}
+
+		amountText := in[match[2]:match[3]]
+		if amount, err := strconv.Atoi(amountText); err == nil {
+			out.months += amount * unit.months
+			out.days += amount * unit.days
+			out.duration += time.Duration(amount) * unit.duration
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(amountText, 64)
+		if err != nil {
+			return out, false
+		}
+
+		exact := time.Duration(unit.months)*30*24*time.Hour + time.Duration(unit.days)*24*time.Hour + unit.duration
+		out.duration += time.Duration(amount * float64(exact))
+	}
+
+	if strings.TrimSpace(in[end:]) != "" {
+		return out, false
+	}
+
+	return out, true
+}
+
+var relativeAgoRegexp = regexp.MustCompile(`^(.+)\s+ago$`)
+var relativeInRegexp = regexp.MustCompile(`^in\s+(.+)$`)
+var relativeTrailingOffsetRegexp = regexp.MustCompile(`^(.*?)\s*([+-])\s*((?:\d+(?:\.\d+)?\s*[a-zA-Zµ]+\s*)+)$`)
+var relativeDayRegexp = regexp.MustCompile(`^(today|yesterday|tomorrow|(?:last|next)\s+[a-z]+)(?:\s+(?:at\s+)?(.+))?$`)
+
+var relativeWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// fromRelativeExpression parses relative date expressions, all relative to [timeNow]:
+//   - `<amount> ago` and `in <amount>` like `3 days ago`, `2h30m ago` or `in 2 weeks`
+//   - `today`, `yesterday`, `tomorrow`, `last <weekday>` and `next <weekday>` resolving to
+//     midnight in `timezone`, optionally followed by a time like `yesterday 15:00`
+//   - any date followed by one or more signed offsets like `now-2h`, `2024-05-01+36h`
+//     or `yesterday 15:00 - 30m`
+//
+// Amounts accept the units ns, us, ms, s, m, h, d (calendar day), w, mo and y, long forms
+// like `days` or `hours` are accepted too.
+func fromRelativeExpression(element string, hint DateLikeHint, timezone *time.Location, trace *DateParseTrace) (out time.Time, ok bool) {
+	expression := strings.ToLower(strings.Join(strings.Fields(element), " "))
+
+	if match := relativeAgoRegexp.FindStringSubmatch(expression); match != nil {
+		if offset, ok := parseRelativeOffset(match[1]); ok {
+			traceRelativeToNow(trace, offset.negate(), timezone)
+			return offset.negate().applyTo(timeNow().In(timezone)), true
+		}
+	}
+
+	if match := relativeInRegexp.FindStringSubmatch(expression); match != nil {
+		if offset, ok := parseRelativeOffset(match[1]); ok {
+			traceRelativeToNow(trace, offset, timezone)
+			return offset.applyTo(timeNow().In(timezone)), true
+		}
+	}
+
+	if out, ok := fromRelativeDay(expression, timezone); ok {
+		trace.Relative = expression
+		trace.Zone = DateZoneSourceTimezoneIfUnset
+		trace.ZoneDetail = timezone.String()
+
+		return out, true
+	}
+
+	// The base is matched on the original element (minus extra spaces) because layouts are case sensitive
+	match := relativeTrailingOffsetRegexp.FindStringSubmatch(strings.Join(strings.Fields(element), " "))
+	if match == nil || match[1] == "" {
+		return out, false
+	}
+
+	offset, ok := parseRelativeOffset(match[3])
+	if !ok {
+		return out, false
+	}
+
+	if match[2] == "-" {
+		offset = offset.negate()
+	}
+
+	base, baseTrace, ok := ExplainDateLikeInput(match[1], hint, timezone)
+	if !ok {
+		return out, false
+	}
+
+	trace.Relative = offset.String()
+	trace.RelativeBase = baseTrace
+
+	return offset.applyTo(base), true
+}
+
+func traceRelativeToNow(trace *DateParseTrace, offset relativeOffset, timezone *time.Location) {
+	trace.Relative = offset.String()
+	trace.RelativeBase = &DateParseTrace{Input: "now", ParsedFrom: DateParsedFromLayout, Zone: DateZoneSourceTimezoneIfUnset, ZoneDetail: timezone.String()}
+}
+
+// fromRelativeDay parses `today`, `yesterday`, `tomorrow`, `last <weekday>` and `next <weekday>`
+// optionally followed by a time of day, see [ParseTimeOnlyInput].
+func fromRelativeDay(expression string, timezone *time.Location) (out time.Time, ok bool) {
+	match := relativeDayRegexp.FindStringSubmatch(expression)
+	if match == nil {
+		return out, false
+	}
+
+	now := timeNow().In(timezone)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timezone)
+
+	switch day := match[1]; day {
+	case "today":
+		out = midnight
+	case "yesterday":
+		out = midnight.AddDate(0, 0, -1)
+	case "tomorrow":
+		out = midnight.AddDate(0, 0, 1)
+	default:
+		direction, name, _ := strings.Cut(day, " ")
+		weekday, found := relativeWeekdays[name]
+		if !found {
+			return out, false
+		}
+
+		if direction == "last" {
+			// Strictly before today, so `last friday` on a Friday is a week ago
+			delta := (int(now.Weekday()) - int(weekday) + 7) % 7
+			if delta == 0 {
+				delta = 7
+			}
+
+			out = midnight.AddDate(0, 0, -delta)
+		} else {
+			// Strictly after today, so `next friday` on a Friday is in a week
+			delta := (int(weekday) - int(now.Weekday()) + 7) % 7
+			if delta == 0 {
+				delta = 7
+			}
+
+			out = midnight.AddDate(0, 0, delta)
+		}
+	}
+
+	if match[2] != "" {
+		timeOfDay, ok := ParseTimeOnlyInput(match[2])
+		if !ok {
+			return out, false
+		}
+
+		hours, minutes, seconds := int(timeOfDay/time.Hour), int(timeOfDay/time.Minute)%60, int(timeOfDay/time.Second)%60
+		out = time.Date(out.Year(), out.Month(), out.Day(), hours, minutes, seconds, int(timeOfDay%time.Second), timezone)
+	}
+
+	return out, true
+}
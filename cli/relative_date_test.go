@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDateLikeInput_Relative(t *testing.T) {
+	// timeNow is fixed to 2024-11-29 08:28:27.718-05:00, a Friday, see `init` in cli_test.go
+	tests := []struct {
+		element string
+		want    time.Time
+		ok      bool
+	}{
+		{"now-2h", date(t, "2024-11-29 06:28:27.718-05:00"), true},
+		{"now - 2h30m", date(t, "2024-11-29 05:58:27.718-05:00"), true},
+		{"now+1d", date(t, "2024-11-30 08:28:27.718-05:00"), true},
+		{"now-1h+30m", date(t, "2024-11-29 07:58:27.718-05:00"), true},
+		{"now - 3 days", date(t, "2024-11-26 08:28:27.718-05:00"), true},
+		{"now-1.5h", date(t, "2024-11-29 06:58:27.718-05:00"), true},
+		{"3 days ago", date(t, "2024-11-26 08:28:27.718-05:00"), true},
+		{"2 hours 30 minutes ago", date(t, "2024-11-29 05:58:27.718-05:00"), true},
+		{"1w ago", date(t, "2024-11-22 08:28:27.718-05:00"), true},
+		{"1 month ago", date(t, "2024-10-29 08:28:27.718-05:00"), true},
+		{"in 2 days", date(t, "2024-12-01 08:28:27.718-05:00"), true},
+		{"today", date(t, "2024-11-29 00:00:00-05:00"), true},
+		{"yesterday", date(t, "2024-11-28 00:00:00-05:00"), true},
+		{"Yesterday 15:00", date(t, "2024-11-28 15:00:00-05:00"), true},
+		{"yesterday at 15:04:05.250", date(t, "2024-11-28 15:04:05.25-05:00"), true},
+		{"tomorrow 09:30", date(t, "2024-11-30 09:30:00-05:00"), true},
+		{"last monday", date(t, "2024-11-25 00:00:00-05:00"), true},
+		{"last friday", date(t, "2024-11-22 00:00:00-05:00"), true},
+		{"next fri", date(t, "2024-12-06 00:00:00-05:00"), true},
+		{"next sunday 10:00", date(t, "2024-12-01 10:00:00-05:00"), true},
+		{"yesterday 15:00 - 30m", date(t, "2024-11-28 14:30:00-05:00"), true},
+		{"2024-05-01+36h", date(t, "2024-05-02 12:00:00-05:00"), true},
+		{"2024-05-01T00:00:00Z+36h", date(t, "2024-05-02 12:00:00Z"), true},
+		{"2023-04-13T14:25:27.180-0400 - 1d", date(t, "2023-04-12 14:25:27.18-04:00"), true},
+		{"1732886907+1h", date(t, "2024-11-29 14:28:27Z"), true},
+
+		{"last someday", time.Time{}, false},
+		{"yesterday noon", time.Time{}, false},
+		{"3 parsecs ago", time.Time{}, false},
+		{"-2h", time.Time{}, false},
+		{"now-2", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			got, parsedFrom, ok := ParseDateLikeInput(tt.element, DateLikeHintNone, testLocation)
+
+			assert.Equal(t, tt.ok, ok, "Date %q parsing result is not as expected", tt.element)
+			if tt.ok {
+				assert.True(t, tt.want.Equal(got), "expected %s, got %s", tt.want.Format(testLayout), got.Format(testLayout))
+				assert.Equal(t, DateParsedFromRelative, parsedFrom)
+			}
+		})
+	}
+}
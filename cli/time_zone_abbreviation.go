@@ -3,7 +3,11 @@ package cli
 import (
 	"bufio"
 	_ "embed"
+	"errors"
+	"fmt"
 	"math"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,17 +17,131 @@ import (
 //go:embed time_zone_abbreviation.csv
 var timeZoneAbbreviationCSV string
 
-var timeZoneAbbreviationToOffsetSecondsFunc = sync.OnceValue(parseTimeZoneAbbreviationLocation)
+var timeZoneAbbreviationCandidatesFunc = sync.OnceValue(parseTimeZoneAbbreviationCandidates)
+
+// ErrUnknownTimeZoneAbbreviation is returned by [ResolveTimeZoneAbbreviation] when the
+// abbreviation is not part of the known abbreviations.
+var ErrUnknownTimeZoneAbbreviation = errors.New("unknown timezone abbreviation")
+
+// TimezoneRegionsEnv is the environment variable holding the preferred timezone regions,
+// see [RegisterTimezoneRegionsFlags].
+const TimezoneRegionsEnv = "SFTOOL_TZ_REGIONS"
+
+// defaultTimezoneRegion is the region used to disambiguate timezone abbreviations when
+// no preferred regions are configured, ambiguous abbreviations resolve to the offset
+// closest to its standard offset (-05:00).
+const defaultTimezoneRegion = "America/New_York"
+
+var timezoneRegionsFlag string
+
+// RegisterTimezoneRegionsFlags registers the shared `-tz-regions` flag on the received flag
+// set, the value is used by [ResolveTimeZoneAbbreviation], see [PreferredTimezoneRegions].
+func RegisterTimezoneRegionsFlags(flags FlagSet) {
+	flags.StringVar(&timezoneRegionsFlag, "tz-regions", "", fmt.Sprintf("Comma separated list of IANA timezones (e.g. 'Europe/London,Asia/Kolkata') tried in order to disambiguate timezone abbreviations like IST or CST, defaults to $%s then to 'timezone_regions' of the tooling config file", TimezoneRegionsEnv))
+}
+
+// PreferredTimezoneRegions returns the IANA timezones used in order to disambiguate timezone
+// abbreviations, taken from the first one defined of the `-tz-regions` flag, the
+// [TimezoneRegionsEnv] environment variable and the `timezone_regions` key of the tooling
+// config file (see [DefaultToolingConfigFile]). If none is defined, `explicit` is false and
+// the regions are `America/New_York`. An invalid tooling config file is reported on standard
+// error and ignored.
+func PreferredTimezoneRegions() (regions []string, explicit bool) {
+	if timezoneRegionsFlag != "" {
+		return splitTimezoneRegions(timezoneRegionsFlag), true
+	}
+
+	if value := os.Getenv(TimezoneRegionsEnv); value != "" {
+		return splitTimezoneRegions(value), true
+	}
+
+	if config := loadToolingConfig(); len(config.TimezoneRegions) > 0 {
+		return config.TimezoneRegions, true
+	}
+
+	return []string{defaultTimezoneRegion}, false
+}
+
+func splitTimezoneRegions(in string) (out []string) {
+	for _, region := range strings.Split(in, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			out = append(out, region)
+		}
+	}
+
+	return
+}
 
 func GetTimeZoneAbbreviationLocation(in string) (*time.Location, bool) {
-	zoneAbbreviationToLocation := timeZoneAbbreviationToOffsetSecondsFunc()
+	location, _, err := ResolveTimeZoneAbbreviation(in)
+	return location, err == nil
+}
+
+// ResolveTimeZoneAbbreviation resolves a timezone abbreviation like `IST` to a fixed offset
+// location, `detail` describes how it was resolved. The first of the [PreferredTimezoneRegions]
+// currently or seasonally using the abbreviation gives the offset. Otherwise, the abbreviation
+// must have a single known offset.
+//
+// When no preferred regions are configured, an ambiguous abbreviation resolves to the known
+// offset closest to -05:00. When they are, an error listing the candidate offsets is returned.
+// The error is [ErrUnknownTimeZoneAbbreviation] if the abbreviation is not known.
+func ResolveTimeZoneAbbreviation(abbreviation string) (location *time.Location, detail string, err error) {
+	candidates, found := timeZoneAbbreviationCandidatesFunc()[abbreviation]
+	if !found {
+		return nil, "", fmt.Errorf("%w %q", ErrUnknownTimeZoneAbbreviation, abbreviation)
+	}
+
+	regions, explicit := PreferredTimezoneRegions()
 
-	location, exists := zoneAbbreviationToLocation[in]
-	return location, exists
+	year := timeNow().Year()
+	for _, region := range regions {
+		regionLocation, err := time.LoadLocation(region)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid preferred timezone region %q: %w", region, err)
+		}
+
+		// Check winter and summer of both hemispheres so daylight saving abbreviations are found too
+		for _, month := range []time.Month{time.January, time.July} {
+			name, offset := time.Date(year, month, 1, 12, 0, 0, 0, regionLocation).Zone()
+			if name == abbreviation {
+				return time.FixedZone(abbreviation, offset), fmt.Sprintf("used by preferred region %s", region), nil
+			}
+		}
+	}
+
+	if len(candidates) == 1 {
+		return time.FixedZone(abbreviation, candidates[0]), "single known offset", nil
+	}
+
+	if explicit {
+		return nil, "", fmt.Errorf("ambiguous timezone abbreviation %q is not used by any of the preferred regions %s, candidate offsets are %s, add a region using it with -tz-regions or use a numeric offset instead",
+			abbreviation, strings.Join(regions, ", "), formatZoneOffsets(candidates))
+	}
+
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		// Compare with -05:00 (i.e. -18000 seconds)
+		if math.Abs(float64(candidate+18000)) < math.Abs(float64(best+18000)) {
+			best = candidate
+		}
+	}
+
+	return time.FixedZone(abbreviation, best), fmt.Sprintf("ambiguous, closest offset to -05:00 among %s, configure preferred regions with -tz-regions", formatZoneOffsets(candidates)), nil
 }
 
-func parseTimeZoneAbbreviationLocation() map[string]*time.Location {
-	bestOffsets := make(map[string]int)
+func formatZoneOffsets(offsets []int) string {
+	formatted := make([]string, len(offsets))
+	for i, offset := range offsets {
+		formatted[i] = formatZoneOffset(offset)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// parseTimeZoneAbbreviationCandidates returns the known offsets of each abbreviation, in
+// the order of the embedded CSV.
+func parseTimeZoneAbbreviationCandidates() map[string][]int {
+	candidates := make(map[string][]int)
 
 	scanner := bufio.NewScanner(strings.NewReader(timeZoneAbbreviationCSV))
 	for scanner.Scan() {
@@ -43,22 +161,10 @@ func parseTimeZoneAbbreviationLocation() map[string]*time.Location {
 			continue
 		}
 
-		// Compare with -05:00 (i.e. -18000 seconds)
-		diff := math.Abs(float64(offset + 18000))
-		if existing, ok := bestOffsets[abbrev]; ok {
-			existingDiff := math.Abs(float64(existing + 18000))
-			if diff < existingDiff {
-				bestOffsets[abbrev] = offset
-			}
-		} else {
-			bestOffsets[abbrev] = offset
+		if !slices.Contains(candidates[abbrev], offset) {
+			candidates[abbrev] = append(candidates[abbrev], offset)
 		}
 	}
 
-	zoneToLocationMap := make(map[string]*time.Location)
-	for abbrev, offset := range bestOffsets {
-		zoneToLocationMap[abbrev] = time.FixedZone(abbrev, offset)
-	}
-
-	return zoneToLocationMap
+	return candidates
 }
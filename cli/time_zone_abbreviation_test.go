@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTimeZoneAbbreviation(t *testing.T) {
+	tests := []struct {
+		abbreviation string
+		regions      string
+		wantOffset   int
+		wantErr      string
+	}{
+		{"IST", "", 2079, ""},
+		{"CST", "", -18000, ""},
+		{"JST", "", 32400, ""},
+		{"IST", "Asia/Kolkata", 19800, ""},
+		{"IST", "America/New_York, Europe/Dublin", 3600, ""},
+		{"BST", "Europe/London", 3600, ""},
+		{"CST", "Asia/Shanghai,America/Chicago", 28800, ""},
+		{"CST", "America/Chicago", -21600, ""},
+		{"JST", "Europe/Paris", 32400, ""},
+		{"CST", "Europe/Paris", 0, `ambiguous timezone abbreviation "CST" is not used by any of the preferred regions Europe/Paris, candidate offsets are -05:00, -06:00, +08:00, +09:00`},
+		{"ZZZT", "", 0, `unknown timezone abbreviation "ZZZT"`},
+		{"IST", "Mars/Olympus", 0, `invalid preferred timezone region "Mars/Olympus"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.abbreviation+" "+tt.regions, func(t *testing.T) {
+			t.Setenv(TimezoneRegionsEnv, tt.regions)
+
+			location, _, err := ResolveTimeZoneAbbreviation(tt.abbreviation)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			name, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, location).Zone()
+			assert.Equal(t, tt.abbreviation, name)
+			assert.Equal(t, tt.wantOffset, offset)
+		})
+	}
+}
+
+func TestPreferredTimezoneRegions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(TimezoneRegionsEnv, "")
+
+	configFile := filepath.Join(home, ".config", "streamingfast", "tooling", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configFile), 0755))
+	require.NoError(t, os.WriteFile(configFile, []byte("timezone_regions: [Europe/Dublin]\n"), 0644))
+
+	previous := loadToolingConfig
+	loadToolingConfig = func() *toolingConfig { return readToolingConfig(io.Discard) }
+	defer func() { loadToolingConfig = previous }()
+
+	regions, explicit := PreferredTimezoneRegions()
+	assert.True(t, explicit)
+	assert.Equal(t, []string{"Europe/Dublin"}, regions)
+
+	t.Setenv(TimezoneRegionsEnv, "Europe/Paris, Asia/Tokyo")
+
+	regions, explicit = PreferredTimezoneRegions()
+	assert.True(t, explicit)
+	assert.Equal(t, []string{"Europe/Paris", "Asia/Tokyo"}, regions)
+
+	timezoneRegionsFlag = "Asia/Kolkata"
+	defer func() { timezoneRegionsFlag = "" }()
+
+	regions, _ = PreferredTimezoneRegions()
+	assert.Equal(t, []string{"Asia/Kolkata"}, regions, "flag must take precedence over environment")
+}
+
+func Test_ParseDateLikeInput_AmbiguousAbbreviation(t *testing.T) {
+	t.Setenv(TimezoneRegionsEnv, "Europe/Paris")
+
+	_, trace, ok := ExplainDateLikeInput("2024-07-23 14:37:10.304 CST", DateLikeHintNone, testLocation)
+	assert.False(t, ok)
+	assert.ErrorContains(t, trace.Err, "candidate offsets are -05:00, -06:00, +08:00, +09:00")
+}
@@ -0,0 +1,12 @@
+package cli
+
+// Tool is a command line tool runnable as its own binary (see `cmd/<name>`) or as a
+// subcommand of the `sftool` multi-call binary.
+type Tool struct {
+	Name  string
+	Short string
+
+	// Main runs the tool, it reads its arguments from [os.Args], the first one being
+	// the tool's name, and may exit the process.
+	Main func()
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// toolingConfig is the content of the tooling config file shared by all tools, see [DefaultToolingConfigFile].
+type toolingConfig struct {
+	// TimezoneRegions are the IANA timezones used to disambiguate timezone abbreviations, see [PreferredTimezoneRegions]
+	TimezoneRegions []string `yaml:"timezone_regions"`
+}
+
+func toolingConfigDirectory() (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine user directory: %w", err)
+	}
+
+	return filepath.Join(userHome, ".config", "streamingfast", "tooling"), nil
+}
+
+// toolingConfigLoader reads the config file shared by all tools, see [DefaultToolingConfigFile]
+var toolingConfigLoader = &ConfigLoader[toolingConfig]{Tool: "config"}
+
+// DefaultToolingConfigFile returns the path of the config file shared by all tools which
+// is `$HOME/.config/streamingfast/tooling/config.yaml`, overridden by the
+// `SFTOOL_CONFIG_CONFIG` environment variable.
+func DefaultToolingConfigFile() (string, error) {
+	return toolingConfigLoader.ResolveFile(ConfigOverrides{})
+}
+
+var loadToolingConfig = sync.OnceValue(func() *toolingConfig {
+	return readToolingConfig(os.Stderr)
+})
+
+// readToolingConfig reads the tooling config file, see [DefaultToolingConfigFile]. An
+// unreadable or invalid file is reported on `warnings` and an empty config is returned so
+// that a broken file does not prevent the tools from working.
+func readToolingConfig(warnings io.Writer) *toolingConfig {
+	if _, err := DefaultToolingConfigFile(); err != nil {
+		// Without a home directory, there is no config to load
+		return &toolingConfig{}
+	}
+
+	loaded, err := toolingConfigLoader.Load(ConfigOverrides{})
+	if err != nil {
+		fmt.Fprintf(warnings, "Ignoring tooling config, defaults are used: %s\n", err)
+		return &toolingConfig{}
+	}
+
+	return loaded.Config
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readToolingConfig(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+		if content == "" {
+			return
+		}
+
+		file, err := DefaultToolingConfigFile()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	}
+
+	tests := []struct {
+		name         string
+		content      string
+		want         *toolingConfig
+		wantWarnings string
+	}{
+		{"missing", "", &toolingConfig{}, ""},
+		{"valid", "timezone_regions: [Asia/Kolkata, Europe/Dublin]\n", &toolingConfig{TimezoneRegions: []string{"Asia/Kolkata", "Europe/Dublin"}}, ""},
+		{"unknown key", "timezone_region: [Asia/Kolkata]\n", &toolingConfig{}, "Ignoring tooling config, defaults are used: invalid config file"},
+		{"invalid yaml", "timezone_regions: [\n", &toolingConfig{}, "Ignoring tooling config, defaults are used: invalid config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfig(t, tt.content)
+
+			warnings := &bytes.Buffer{}
+			assert.Equal(t, tt.want, readToolingConfig(warnings))
+
+			if tt.wantWarnings == "" {
+				assert.Empty(t, warnings.String())
+			} else {
+				assert.Contains(t, warnings.String(), tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/streamingfast/tooling/tools/convert"
+)
+
+func main() {
+	convert.Main()
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/streamingfast/tooling/tools/inspect"
+)
+
+func main() {
+	inspect.Main()
+}
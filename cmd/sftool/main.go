@@ -0,0 +1,248 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+	ansistrip "github.com/streamingfast/tooling/tools/ansi_strip"
+	bytes "github.com/streamingfast/tooling/tools/bytes"
+	cbtkey "github.com/streamingfast/tooling/tools/cbt_key"
+	colmap "github.com/streamingfast/tooling/tools/colmap"
+	convert "github.com/streamingfast/tooling/tools/convert"
+	countper "github.com/streamingfast/tooling/tools/count_per"
+	deltas "github.com/streamingfast/tooling/tools/deltas"
+	endian "github.com/streamingfast/tooling/tools/endian"
+	fastkill "github.com/streamingfast/tooling/tools/fast_kill"
+	gcscopy "github.com/streamingfast/tooling/tools/gcs_copy"
+	gcsfastdelete "github.com/streamingfast/tooling/tools/gcs_fast_delete"
+	gcsupload "github.com/streamingfast/tooling/tools/gcs_upload"
+	ghclone "github.com/streamingfast/tooling/tools/gh_clone"
+	gobump "github.com/streamingfast/tooling/tools/go_bump"
+	goreplace "github.com/streamingfast/tooling/tools/go_replace"
+	inspect "github.com/streamingfast/tooling/tools/inspect"
+	kcctx "github.com/streamingfast/tooling/tools/kcctx"
+	rateof "github.com/streamingfast/tooling/tools/rate_of"
+	restring "github.com/streamingfast/tooling/tools/re_string"
+	sets "github.com/streamingfast/tooling/tools/sets"
+	sfenv "github.com/streamingfast/tooling/tools/sfenv"
+	skip "github.com/streamingfast/tooling/tools/skip"
+	stats "github.com/streamingfast/tooling/tools/stats"
+	toascii "github.com/streamingfast/tooling/tools/to_ascii"
+	tobase58 "github.com/streamingfast/tooling/tools/to_base58"
+	tobase64 "github.com/streamingfast/tooling/tools/to_base64"
+	tobech32 "github.com/streamingfast/tooling/tools/to_bech32"
+	todate "github.com/streamingfast/tooling/tools/to_date"
+	todec "github.com/streamingfast/tooling/tools/to_dec"
+	toduration "github.com/streamingfast/tooling/tools/to_duration"
+	tohex "github.com/streamingfast/tooling/tools/to_hex"
+	tolower "github.com/streamingfast/tooling/tools/to_lower"
+	totimestamp "github.com/streamingfast/tooling/tools/to_timestamp"
+	toupper "github.com/streamingfast/tooling/tools/to_upper"
+	tourl "github.com/streamingfast/tooling/tools/to_url"
+)
+
+// tools are all the tools bundled in `sftool`, each also has its own `cmd/<name>` binary
+var tools = []cli.Tool{
+	ansistrip.Tool,
+	bytes.Tool,
+	cbtkey.Tool,
+	colmap.Tool,
+	convert.Tool,
+	countper.Tool,
+	deltas.Tool,
+	endian.Tool,
+	fastkill.Tool,
+	gcscopy.Tool,
+	gcsfastdelete.Tool,
+	gcsupload.Tool,
+	ghclone.Tool,
+	gobump.Tool,
+	goreplace.Tool,
+	inspect.Tool,
+	kcctx.Tool,
+	rateof.Tool,
+	restring.Tool,
+	sets.Tool,
+	sfenv.Tool,
+	skip.Tool,
+	stats.Tool,
+	toascii.Tool,
+	tobase58.Tool,
+	tobase64.Tool,
+	tobech32.Tool,
+	todate.Tool,
+	todec.Tool,
+	toduration.Tool,
+	tohex.Tool,
+	tolower.Tool,
+	totimestamp.Tool,
+	toupper.Tool,
+	tourl.Tool,
+}
+
+func main() {
+	// Busybox style, invoked through a link named after one of the tools
+	if tool, found := findTool(toolName(os.Args[0])); found {
+		tool.Main()
+		return
+	}
+
+	if len(os.Args) < 2 {
+		printUsage(os.Stderr)
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout)
+		return
+
+	case "install-links":
+		runInstallLinks(os.Args[2:])
+		return
+	}
+
+	tool, found := findTool(os.Args[1])
+	cli.Ensure(found, "Unknown tool %q, run 'sftool help' to list the available ones", os.Args[1])
+
+	// The tool parses its arguments from os.Args, the first one being its name
+	os.Args = os.Args[1:]
+	tool.Main()
+}
+
+func toolName(executable string) string {
+	return strings.TrimSuffix(filepath.Base(executable), ".exe")
+}
+
+func findTool(name string) (cli.Tool, bool) {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+
+	return cli.Tool{}, false
+}
+
+func printUsage(writer io.Writer) {
+	fmt.Fprintln(writer, "Usage: sftool <tool> [<argument> ...]")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "Each tool can also be invoked directly through a link named after it, see 'sftool install-links <dir>'.")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "Tools:")
+
+	width := 0
+	for _, tool := range tools {
+		width = max(width, len(tool.Name))
+	}
+
+	for _, tool := range tools {
+		fmt.Fprintf(writer, "  %-*s  %s\n", width, tool.Name, tool.Short)
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "Commands:")
+	fmt.Fprintf(writer, "  %-*s  %s\n", width, "install-links", "Creates a link named after each tool to this binary in <dir>")
+}
+
+func runInstallLinks(args []string) {
+	flags := flag.NewFlagSet("install-links", flag.ExitOnError)
+	force := flags.Bool("f", false, "Replace existing files that are not already a link to this binary, after confirmation")
+	cli.RegisterConfirmFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sftool install-links [-f [-yes]] <dir>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	cli.Ensure(flags.NArg() == 1, "Expecting exactly one argument, the directory where to create the links")
+
+	target, err := os.Executable()
+	cli.NoError(err, "unable to determine sftool executable path")
+
+	target, err = filepath.EvalSymlinks(target)
+	cli.NoError(err, "unable to resolve sftool executable path")
+
+	if replaced := linksToReplace(flags.Arg(0), target); *force && len(replaced) > 0 {
+		confirmed, err := cli.Confirm(fmt.Sprintf("Replace %d existing file(s) (%s)?", len(replaced), strings.Join(replaced, ", ")), cli.WithConfirmDefault(false))
+		cli.NoError(err, "unable to confirm replacing existing files")
+		if !confirmed {
+			cli.Quit("Aborted, no link installed, use -yes to replace existing files without confirmation")
+		}
+	}
+
+	results, err := installLinks(flags.Arg(0), target, *force)
+	for _, result := range results {
+		fmt.Println(result)
+	}
+
+	cli.NoError(err, "unable to install links")
+}
+
+// linksToReplace returns the paths in `dir` named after a tool that exist but are not
+// already a link to `target`
+func linksToReplace(dir string, target string) (out []string) {
+	for _, tool := range tools {
+		path := filepath.Join(dir, tool.Name)
+
+		if existing, err := os.Readlink(path); err == nil && existing == target {
+			continue
+		}
+
+		if _, err := os.Lstat(path); err == nil {
+			out = append(out, path)
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+// installLinks creates in `dir` a symbolic link to `target` for each tool, returning one
+// line per tool describing what was done. Existing files that are not already a link to
+// `target` are kept unless `force` is true.
+func installLinks(dir string, target string, force bool) (results []string, err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create directory %q: %w", dir, err)
+	}
+
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+
+		if existing, err := os.Readlink(path); err == nil && existing == target {
+			results = append(results, fmt.Sprintf("%s (up to date)", path))
+			continue
+		}
+
+		if _, err := os.Lstat(path); err == nil {
+			if !force {
+				results = append(results, fmt.Sprintf("%s (skipped, already exists, use -f to replace it)", path))
+				continue
+			}
+
+			if err := os.Remove(path); err != nil {
+				return results, fmt.Errorf("remove existing %q: %w", path, err)
+			}
+		}
+
+		if err := os.Symlink(target, path); err != nil {
+			return results, fmt.Errorf("link %q: %w", path, err)
+		}
+
+		results = append(results, fmt.Sprintf("%s -> %s", path, target))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_installLinks(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "bin")
+	target := "/usr/local/bin/sftool"
+
+	results, err := installLinks(dir, target, false)
+	require.NoError(t, err)
+	assert.Len(t, results, len(tools))
+	assertLink(t, filepath.Join(dir, "to_hex"), target)
+
+	results, err = installLinks(dir, target, false)
+	require.NoError(t, err)
+	assert.Contains(t, results, filepath.Join(dir, "to_hex")+" (up to date)")
+
+	existing := filepath.Join(dir, "to_dec")
+	require.NoError(t, os.Remove(existing))
+	require.NoError(t, os.WriteFile(existing, []byte("#!/bin/sh"), 0755))
+	assert.Equal(t, []string{existing}, linksToReplace(dir, target))
+
+	results, err = installLinks(dir, target, false)
+	require.NoError(t, err)
+	assert.Contains(t, results, existing+" (skipped, already exists, use -f to replace it)")
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh", string(content))
+
+	results, err = installLinks(dir, target, true)
+	require.NoError(t, err)
+	assert.Contains(t, results, existing+" -> "+target)
+	assertLink(t, existing, target)
+}
+
+func Test_toolName(t *testing.T) {
+	tests := []struct {
+		arg0     string
+		expected string
+	}{
+		{"sftool", "sftool"},
+		{"/usr/local/bin/to_hex", "to_hex"},
+		{`to_hex.exe`, "to_hex"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.arg0, func(t *testing.T) {
+			assert.Equal(t, test.expected, toolName(test.arg0))
+		})
+	}
+}
+
+func assertLink(t *testing.T, path string, expected string) {
+	t.Helper()
+
+	actual, err := os.Readlink(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
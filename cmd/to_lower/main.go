@@ -1,15 +1,9 @@
 package main
 
 import (
-	"fmt"
-	"strings"
-
-	"github.com/streamingfast/tooling/cli"
+	tolower "github.com/streamingfast/tooling/tools/to_lower"
 )
 
 func main() {
-	scanner := cli.NewOsArgumentScanner()
-	for element, ok := scanner.ScanArgument(); ok; element, ok = scanner.ScanArgument() {
-		fmt.Println(strings.ToLower(element))
-	}
+	tolower.Main()
 }
@@ -1,15 +1,9 @@
 package main
 
 import (
-	"fmt"
-	"strings"
-
-	"github.com/streamingfast/tooling/cli"
+	toupper "github.com/streamingfast/tooling/tools/to_upper"
 )
 
 func main() {
-	scanner := cli.NewOsArgumentScanner()
-	for element, ok := scanner.ScanArgument(); ok; element, ok = scanner.ScanArgument() {
-		fmt.Println(strings.ToUpper(element))
-	}
+	toupper.Main()
 }
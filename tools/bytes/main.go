@@ -0,0 +1,92 @@
+package bytes
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("bytes", flag.ExitOnError)
+
+// Tool is bytes runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "bytes", Short: "Humanizes bytes value", Main: Main}
+
+var asBinary = flags.Bool("b", false, "Use IEC base 2 representation for bytes, i.e. KiB = 1024, MiB = 1024^2, etc.")
+var asInternational = flags.Bool("si", false, "Use International System of Units (SI) base 10 representation for bytes, i.e. KB = 1000, MB = 1000^2, etc.")
+var compact = flags.Bool("c", false, "Compact output giving only one of -b or -si, depending on which one is used.")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterInlineFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cli.Ensure(!(*asBinary && *asInternational), "You cannot use both -b and -si flags at the same time")
+
+	converter := humanize
+	if cli.InlineEnabled() {
+		// A single representation is used when rewriting tokens inside a line
+		*compact = true
+		// Only the numbers having a bytes unit are rewritten, a bare number may count anything
+		converter = cli.InlineConverter(cli.BytesTokenMatcher, humanize)
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), converter)
+}
+
+func humanize(element string) (string, string, error) {
+	if cli.DecRegexp.MatchString(element) {
+		value, ok := new(big.Int).SetString(element, 10)
+		if !ok {
+			return "", "", fmt.Errorf("invalid decimal value %q", element)
+		}
+
+		return humanizeBytes(value), "decimal", nil
+	}
+
+	if cli.HexRegexp.MatchString(element) {
+		value, ok := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(element), "0x"), 16)
+		if !ok {
+			return "", "", fmt.Errorf("invalid hex value %q", element)
+		}
+
+		return humanizeBytes(value), "hex", nil
+	}
+
+	// Re-humanize quantities already having a unit like `1536 MB` or `12582912 B/s`
+	quantity, err := cli.ParseQuantity(element)
+	if err == nil {
+		switch {
+		case quantity.Kind == cli.QuantityKindBytes:
+			return humanizeBytesFloat(quantity.Value, ""), "bytes", nil
+		case quantity.Kind == cli.QuantityKindRate && quantity.RateUnit == "B":
+			return humanizeBytesFloat(quantity.Value, "/s"), "rate", nil
+		}
+	}
+
+	return element, "", nil
+}
+
+func humanizeBytes(value *big.Int) string {
+	return pickRepresentation(cli.FormatBigBytes(value, true), cli.FormatBigBytes(value, false))
+}
+
+func humanizeBytesFloat(value float64, suffix string) string {
+	return pickRepresentation(cli.FormatBytes(value, true)+suffix, cli.FormatBytes(value, false)+suffix)
+}
+
+// pickRepresentation returns both representations or only one of them with -c
+func pickRepresentation(inBinary, inInternational string) string {
+	if *compact {
+		if *asInternational {
+			return inInternational
+		}
+
+		return inBinary
+	}
+
+	return fmt.Sprintf("%s (%s)", inBinary, inInternational)
+}
@@ -0,0 +1,166 @@
+package cbtkey
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+// shellKey formats `bytes` as a bash ANSI-C quoted string escaping every byte, like $'\x01\x02'
+func shellKey(bytes []byte) string {
+	builder := strings.Builder{}
+	builder.WriteString("$'")
+	for _, byteValue := range bytes {
+		fmt.Fprintf(&builder, `\x%02x`, byteValue)
+	}
+	builder.WriteString("'")
+
+	return builder.String()
+}
+
+var simpleEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 'e': 0x1b, 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"',
+}
+
+// decodeKey decodes a key written with bash ANSI-C escapes, as printed by this tool or by
+// `cbt read`, to its bytes. The key can be wrapped in $'...', `\xHH` (hexadecimal), `\NNN`
+// (octal) and the usual `\n`, `\t`, `\\`, etc. escapes are decoded and any other character
+// is taken as is.
+func decodeKey(in string) ([]byte, error) {
+	if strings.HasPrefix(in, "$'") && strings.HasSuffix(in, "'") && len(in) >= 3 {
+		in = in[2 : len(in)-1]
+	}
+
+	out := make([]byte, 0, len(in))
+	for i := 0; i < len(in); i++ {
+		if in[i] != '\\' {
+			out = append(out, in[i])
+			continue
+		}
+
+		if i+1 >= len(in) {
+			return nil, fmt.Errorf("unterminated escape sequence at the end of %q", in)
+		}
+
+		i++
+		switch escape := in[i]; {
+		case escape == 'x':
+			if i+3 > len(in) {
+				return nil, fmt.Errorf("escape sequence \\x at offset %d requires 2 hexadecimal digits", i-1)
+			}
+
+			value, err := strconv.ParseUint(in[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("escape sequence \\x at offset %d requires 2 hexadecimal digits", i-1)
+			}
+
+			out = append(out, byte(value))
+			i += 2
+
+		case escape >= '0' && escape <= '7':
+			end := i + 1
+			for end < len(in) && end < i+3 && in[end] >= '0' && in[end] <= '7' {
+				end++
+			}
+
+			value, err := strconv.ParseUint(in[i:end], 8, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid octal escape sequence \\%s at offset %d", in[i:end], i-1)
+			}
+
+			out = append(out, byte(value))
+			i = end - 1
+
+		default:
+			value, found := simpleEscapes[escape]
+			if !found {
+				return nil, fmt.Errorf("unknown escape sequence \\%c at offset %d", escape, i-1)
+			}
+
+			out = append(out, value)
+		}
+	}
+
+	return out, nil
+}
+
+var integerSegmentRegexp = regexp.MustCompile(`^u(8|16|32|64)(le|rev)?$`)
+
+// composeKey builds a key from its comma separated segments `spec`, each being `<type>:<value>`:
+//
+//   - `str:<text>` the text bytes, escapes are decoded like [decodeKey] does (e.g. `\x2c` for a comma)
+//   - `hex:<hex>` the bytes represented by the hexadecimal value
+//   - `u8`, `u16`, `u32` or `u64:<integer>` the unsigned integer as big-endian bytes, `le` suffixed
+//     (e.g. `u32le`) for little-endian, `rev` suffixed (e.g. `u64rev`) for the reversed encoding of
+//     `to_hex -i -r` where each byte is inverted so greater values sort first
+func composeKey(spec string) ([]byte, error) {
+	var out []byte
+	for i, segment := range strings.Split(spec, ",") {
+		kind, value, found := strings.Cut(segment, ":")
+		if !found {
+			return nil, fmt.Errorf("segment #%d %q must be <type>:<value>", i+1, segment)
+		}
+
+		bytes, err := composeSegment(kind, value)
+		if err != nil {
+			return nil, fmt.Errorf("segment #%d %q: %w", i+1, segment, err)
+		}
+
+		out = append(out, bytes...)
+	}
+
+	return out, nil
+}
+
+func composeSegment(kind string, value string) ([]byte, error) {
+	switch kind {
+	case "str", "s":
+		return decodeKey(value)
+
+	case "hex", "h":
+		digits := strings.TrimPrefix(value, "0x")
+		if len(digits)%2 != 0 {
+			return nil, fmt.Errorf("hexadecimal value has an odd number of digits")
+		}
+
+		return hex.DecodeString(digits)
+	}
+
+	matches := integerSegmentRegexp.FindStringSubmatch(kind)
+	if matches == nil {
+		return nil, fmt.Errorf("unknown segment type %q, valid types are str, hex, u8, u16, u32 and u64 optionally suffixed by le or rev", kind)
+	}
+
+	number, err := cli.ParseNumber(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if number.Sign() < 0 {
+		return nil, fmt.Errorf("value must be positive")
+	}
+
+	bits, _ := strconv.Atoi(matches[1])
+	bytes, err := cli.NumberToBytes(number, bits/8)
+	if err != nil {
+		return nil, err
+	}
+
+	switch matches[2] {
+	case "le":
+		slices.Reverse(bytes)
+
+	case "rev":
+		for i := range bytes {
+			bytes[i] ^= 0xff
+		}
+	}
+
+	return bytes, nil
+}
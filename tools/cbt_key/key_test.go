@@ -0,0 +1,78 @@
+package cbtkey
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{`$'\x01\xa0\x05'`, "01a005", ""},
+		{`\x01\xA0bk:`, "01a0626b3a", ""},
+		{`bk:`, "626b3a", ""},
+		{`\n\t\\\'\"`, "0a095c2722", ""},
+		{`\0\101\1012`, "00414132", ""},
+		{`$'`, "2427", ""},
+
+		{`bk:\x`, "", `escape sequence \x at offset 3 requires 2 hexadecimal digits`},
+		{`\xzz`, "", `escape sequence \x at offset 0 requires 2 hexadecimal digits`},
+		{`\q`, "", `unknown escape sequence \q at offset 0`},
+		{`ab\`, "", `unterminated escape sequence at the end of "ab\\"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := decodeKey(tt.in)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func Test_composeKey(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr string
+	}{
+		{"str:bk:,u64rev:12345,hex:abcd", "626b3affffffffffffcfc6abcd", ""},
+		{"u8:1,u16:258,u32le:258,u64:0x10", "010102020100000000000000000010", ""},
+		{`s:a\x2cb,h:0x01`, "612c6201", ""},
+
+		{"str", "", `segment #1 "str" must be <type>:<value>`},
+		{"u8:256", "", `segment #1 "u8:256": value 256 does not fit in 1 bytes`},
+		{"hex:ab,u32:-1", "", `segment #2 "u32:-1": value must be positive`},
+		{"hex:abc", "", `segment #1 "hex:abc": hexadecimal value has an odd number of digits`},
+		{"u12:1", "", `segment #1 "u12:1": unknown segment type "u12"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := composeKey(tt.spec)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func Test_shellKey(t *testing.T) {
+	assert.Equal(t, `$'\x01\xa0\x05'`, shellKey([]byte{0x01, 0xa0, 0x05}))
+	assert.Equal(t, `$''`, shellKey(nil))
+}
@@ -0,0 +1,106 @@
+package cbtkey
+
+import (
+	"encoding/hex"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/tooling/cli"
+)
+
+// Tool is cbt_key runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "cbt_key", Short: "Turns an hexadecimal input into a Cloud Bigtable raw bytes key and back", Main: Main}
+
+func Main() {
+	Run(
+		"cbt_key",
+		"Turns an hexadecimal input into cbt raw bytes key formatted like $'\x01\x02'",
+		Description(`
+			The goal of this command is to make it simpler when using 'cbt read prefix=<key>'
+			to input hexadecimal key directly
+
+			With --decode, a key escaped like $'\x01\xa0bk:' (the $'...' is optional), as copied
+			from 'cbt read' output, is turned back into hexadecimal.
+
+			With --compose, the key is built from comma separated <type>:<value> segments, the
+			hexadecimal key and its cbt form are printed separated by a space. The types are:
+			- str:<text> the text bytes, escapes are decoded like --decode does (\x2c for a comma)
+			- hex:<hex> the bytes of the hexadecimal value
+			- u8, u16, u32 or u64:<integer> the big-endian unsigned integer, suffixed by le for
+			  little-endian (u32le) or by rev for the reversed encoding of 'to_hex -i -r' (u64rev)
+		`),
+		Example(`
+			# Would prints $'\x01\xa0\x05'
+			cbt_key 01a005
+
+			# Would prints 01a0626b3a
+			cbt_key --decode '\x01\xa0bk:'
+
+			# Would prints 626b3affffffffffffcfc6abcd $'\x62\x6b\x3a...\xab\xcd'
+			cbt_key --compose str:bk:,u64rev:12345,hex:abcd
+		`),
+		Flags(func(flags *pflag.FlagSet) {
+			cli.RegisterConverterFlags(flags)
+			flags.BoolP("decode", "d", false, "Decode escaped keys like $'\\x01\\xa0bk:' back to hexadecimal")
+			flags.BoolP("compose", "c", false, "Compose keys from segments like 'str:bk:,u64rev:12345,hex:abcd' and print them as hexadecimal and cbt form")
+		}),
+		Execute(func(cmd *cobra.Command, args []string) error {
+			decode := sflags.MustGetBool(cmd, "decode")
+			compose := sflags.MustGetBool(cmd, "compose")
+			cli.Ensure(!decode || !compose, "Flags --decode and --compose cannot be used together")
+
+			converter := cbtKey
+			switch {
+			case decode:
+				converter = decodeCbtKey
+			case compose:
+				converter = composeCbtKey
+			}
+
+			cli.ConvertArguments(cli.NewArgumentScanner(args), converter)
+
+			return nil
+		}),
+	)
+}
+
+var hexRegex = regexp.MustCompile("^(0x)?[a-fA-F0-9]+$")
+
+func cbtKey(in string) (string, string, error) {
+	if !hexRegex.MatchString(in) {
+		return in, "", nil
+	}
+
+	bytes, _ := cli.DecodeHex(in)
+
+	return shellKey(bytes), "hex", nil
+}
+
+func decodeCbtKey(in string) (string, string, error) {
+	if in == "" {
+		return "", "", nil
+	}
+
+	bytes, err := decodeKey(in)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(bytes), "escaped", nil
+}
+
+func composeCbtKey(in string) (string, string, error) {
+	if in == "" {
+		return "", "", nil
+	}
+
+	bytes, err := composeKey(in)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(bytes) + " " + shellKey(bytes), "spec", nil
+}
@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("convert", flag.ExitOnError)
+
+// Tool is convert runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "convert", Short: "Converts input from any encoding to any other one", Main: Main}
+
+var toFlag = flags.String("to", "", "Encode the output using this codec (required), see the codecs below")
+var fromStdIn = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a bytes stream")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	cli.SetupFlagSet(flags, usage)
+
+	cli.Ensure(*toFlag != "", "Flag -to is required\n\n%s", usage())
+
+	outputCodec, err := cli.LookupCodec(*toFlag)
+	cli.NoError(err, "invalid output codec")
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	if *fromStdIn {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		cli.ProcessInputBytes(flags.Args(), outputCodec.Alignment(), func(bytes []byte) {
+			out, err := outputCodec.Encode(bytes)
+			cli.NoError(err, "unable to encode standard input")
+
+			fmt.Print(out)
+		})
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		if element == "" {
+			return "", "", nil
+		}
+
+		return cli.Convert(element, inputCodec, outputCodec)
+	})
+}
+
+func usage() string {
+	return `usage: convert [-from <codec>] -to <codec> [<value> ...]
+
+Decodes each value with the input codec, inferred when not provided (hex, or string if
+wrapped with double-quotes), and encodes the bytes with the output codec.
+
+Flags:
+` + cli.FlagSetUsage(flags) + `
+Codecs:
+` + cli.CodecsUsage() + `
+
+Examples:
+  # Hexadecimal to base58
+  convert -to base58 abfe0102
+
+  # URL base64 (padded or not) to bech32
+  convert -from b64u -to bech32:cosmos q_4BAg
+
+  # Bytes of standard input to raw standard base64
+  head -c 16 /dev/random | convert -in -to base64raw
+`
+}
@@ -0,0 +1,281 @@
+package deltas
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/tooling/cli"
+)
+
+// Tool is deltas runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "deltas", Short: "Computes deltas between successive lines", Main: Main}
+
+const dayDuration = 24 * time.Hour
+
+func Main() {
+	Run(
+		"deltas <line> [<line> ...]",
+		"Compute the delta between subsequent lines of output (prints it at end of read line)",
+		Description(`
+			For a list of received lines, compute the delta between two consecutive lines and print
+			it, appending it at the end of the line.
+
+			At least two line is required, if a single line is received, an error is emitted.
+
+			The line can contain numbers (integers), dates, time-only values (e.g., 19:25:00.949) or
+			quantities like bytes (1.5 GiB), durations (1h 30m) and rates (12 MiB/s, 400 msg/s).
+			For time-only values, rollover is handled correctly (23:59:00 followed by 00:01:00
+			will show a delta of 2m0s, not -23h58m0s).
+		`),
+		Example(`
+			# Print the deltas from the lines from 'stdin'
+			deltas
+
+			# Print the deltas from provided values from terminal arguments
+			deltas 10 12 14
+
+			# Print the deltas between quantities having a unit
+			deltas "1.5 GiB" "2 GiB" "1800 MiB"
+
+			# Print the deltas from time-only values (handles rollover)
+			deltas 23:59:00 00:01:00
+
+			# Print the deltas between log lines, using the timestamp found in each line
+			kubectl logs <pod> | deltas --extract
+
+		`),
+		Flags(func(flags *pflag.FlagSet) {
+			cli.RegisterConverterFlags(flags)
+			cli.RegisterTimezoneRegionsFlags(flags)
+			cli.RegisterDateExtractionFlags(flags)
+		}),
+		Execute(func(_ *cobra.Command, args []string) error {
+			scanner := cli.NewArgumentScanner(args)
+			emitter := cli.NewEmitter()
+
+			var previous *value
+			var previousTimestamp time.Time
+			var previousTimeOnly time.Duration
+			var hasTimeOnly bool
+
+			var lineCount uint
+			for element, ok, err := cli.ScanElement(scanner); ok; element, ok, err = cli.ScanElement(scanner) {
+				lineCount++
+
+				if err != nil {
+					emitter.Emit(cli.Result{Input: element, Error: err, Line: cli.ElementLine(scanner)})
+					continue
+				}
+
+				if cli.DateExtractionEnabled() {
+					match, err := cli.ExtractDateLikeInputFromFlags(element, time.Local)
+					if err != nil {
+						emitter.Emit(cli.Result{Input: element, Error: err, Line: cli.ElementLine(scanner)})
+						continue
+					}
+
+					if previousTimestamp.IsZero() {
+						previousTimestamp = match.Time
+
+						emitDelta(emitter, element, "-", "timestamp")
+						continue
+					}
+
+					emitDelta(emitter, element, formatDurationDelta(match.Time.Sub(previousTimestamp)), "timestamp")
+					previousTimestamp = match.Time
+					continue
+				}
+
+				// First try time-only parsing (e.g., 19:25:00.949)
+				timeOnly, parsedTimeOnly := cli.ParseTimeOnlyInput(element)
+				if parsedTimeOnly {
+					if !hasTimeOnly {
+						hasTimeOnly = true
+						previousTimeOnly = timeOnly
+
+						emitDelta(emitter, element, "-", "time")
+						continue
+					}
+
+					// We had a previous element, compute the delta with rollover support
+					emitDelta(emitter, element, formatDurationDelta(computeTimeOnlyDelta(previousTimeOnly, timeOnly)), "time")
+					previousTimeOnly = timeOnly
+					continue
+				}
+
+				timestamp, parsedFrom, ok := cli.ParseDateLikeInput(element, cli.DateLikeHintNone, time.Local)
+				if ok && parsedFrom != cli.DateParsedFromTimestamp {
+					if previousTimestamp.IsZero() {
+						previousTimestamp = timestamp
+
+						emitDelta(emitter, element, "-", "timestamp")
+						continue
+					}
+
+					// We had a previous element, compute the delta
+					emitDelta(emitter, element, formatDurationDelta(timestamp.Sub(previousTimestamp)), "timestamp")
+					previousTimestamp = timestamp
+				} else {
+					current, err := toValue(element, previous)
+					if err != nil {
+						emitter.Emit(cli.Result{Input: element, Error: err, Line: cli.ElementLine(scanner)})
+						continue
+					}
+
+					if previous == nil {
+						previous = &current
+
+						emitDelta(emitter, element, "-", current.kind())
+						continue
+					}
+
+					// We had a previous element, compute the delta
+					emitDelta(emitter, element, formatValueDelta(current, *previous), current.kind())
+					previous = &current
+				}
+
+			}
+
+			emitter.Close()
+
+			cli.Ensure(lineCount >= 2, "At least 2 lines is required for this tool, received %d", lineCount)
+			emitter.Errors().ExitOnFailures(int(lineCount))
+
+			return nil
+		}),
+	)
+}
+
+// emitDelta emits the delta of element, in text mode the delta is appended to the element
+// between parentheses, `-` being used as the delta of the first element
+func emitDelta(emitter *cli.Emitter, element string, delta string, kind string) {
+	output := delta
+	if emitter.IsText() {
+		output = fmt.Sprintf("%s (%s)", element, delta)
+	}
+
+	emitter.Emit(cli.Result{Input: element, Output: output, Kind: kind})
+}
+
+// formatDurationDelta formats a delta with an explicit `+` sign when positive, the
+// negative sign is already added by the duration String() method
+func formatDurationDelta(delta time.Duration) string {
+	if delta <= 0 {
+		return delta.String()
+	}
+
+	return "+" + delta.String()
+}
+
+// computeTimeOnlyDelta computes the delta between two time-only values,
+// handling rollover correctly. If the current time is before the previous time
+// (e.g., 23:59:00 -> 00:01:00), it assumes a day rollover occurred and
+// adds 24 hours to compute the correct positive delta.
+func computeTimeOnlyDelta(previous, current time.Duration) time.Duration {
+	delta := current - previous
+
+	// If delta is negative, assume we rolled over midnight
+	if delta < 0 {
+		delta += dayDuration
+	}
+
+	return delta
+}
+
+// value is a number or a quantity, integers are kept exact so that their delta has no
+// precision loss however large they are
+type value struct {
+	quantity cli.Quantity
+	integer  *big.Int
+}
+
+func (v value) kind() string {
+	if v.integer != nil {
+		return "integer"
+	}
+
+	return string(v.quantity.Kind)
+}
+
+// toValue parses element as an integer or a [cli.Quantity] which must be of the same kind
+// as the previous value, if any
+func toValue(element string, previous *value) (value, error) {
+	var previousQuantity *cli.Quantity
+	if previous != nil {
+		previousQuantity = &previous.quantity
+	}
+
+	if integer, err := toNumber(element); err == nil {
+		asFloat, _ := new(big.Float).SetInt(integer).Float64()
+		current := value{quantity: cli.Quantity{Kind: cli.QuantityKindNumber, Value: asFloat}, integer: integer}
+
+		if previousQuantity != nil && previousQuantity.Kind != cli.QuantityKindNumber {
+			return current, fmt.Errorf("cannot compute delta between %s and %s", previousQuantity, element)
+		}
+
+		return current, nil
+	}
+
+	quantity, err := toQuantity(element, previousQuantity)
+	if err != nil {
+		return value{}, err
+	}
+
+	return value{quantity: quantity}, nil
+}
+
+// formatValueDelta formats the delta between two values with an explicit `+` sign when
+// positive, the delta of two integers is computed exactly
+func formatValueDelta(current, previous value) string {
+	if current.integer == nil || previous.integer == nil {
+		return formatQuantityDelta(current.quantity, previous.quantity)
+	}
+
+	delta := new(big.Int).Sub(current.integer, previous.integer)
+	if delta.Sign() <= 0 {
+		// Sign is removed because it's either 0 or negative, if negative, the `String()` call is going to add it
+		return delta.String()
+	}
+
+	return "+" + delta.String()
+}
+
+func toNumber(element string) (*big.Int, error) {
+	if element == "" {
+		return big.NewInt(0), nil
+	}
+
+	return cli.ParseInteger(element)
+}
+
+// toQuantity parses element as a [cli.Quantity] which must be of the same kind as the
+// previous one, if any
+func toQuantity(element string, previous *cli.Quantity) (cli.Quantity, error) {
+	quantity, err := cli.ParseQuantity(element)
+	if err != nil {
+		return quantity, err
+	}
+
+	if previous != nil && (previous.Kind != quantity.Kind || previous.RateUnit != quantity.RateUnit) {
+		return quantity, fmt.Errorf("cannot compute delta between %s and %s", previous, quantity)
+	}
+
+	return quantity, nil
+}
+
+// formatQuantityDelta formats the delta between two quantities of the same kind with an
+// explicit `+` sign when positive, formatted in the unit style of `current`
+func formatQuantityDelta(current, previous cli.Quantity) string {
+	delta := current
+	delta.Value = current.Value - previous.Value
+
+	if delta.Value <= 0 {
+		return delta.String()
+	}
+
+	return "+" + delta.String()
+}
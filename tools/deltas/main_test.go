@@ -1,10 +1,11 @@
-package main
+package deltas
 
 import (
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_computeTimeOnlyDelta(t *testing.T) {
@@ -95,3 +96,70 @@ func Test_computeTimeOnlyDelta(t *testing.T) {
 		})
 	}
 }
+
+func Test_formatQuantityDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		current  string
+		want     string
+	}{
+		{"bytes increase", "1.5 GiB", "2 GiB", "+512.00 MiB"},
+		{"bytes decrease", "2 GiB", "1800 MiB", "-248.00 MiB"},
+		{"durations", "5m", "1h 30m", "+1h 25m 0s"},
+		{"rates", "10 msg/s", "5 msg/s", "-5 msg/s"},
+		{"decimal numbers", "1.5", "2.25", "+0.750"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, err := toQuantity(tt.previous, nil)
+			require.NoError(t, err)
+
+			current, err := toQuantity(tt.current, &previous)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, formatQuantityDelta(current, previous))
+		})
+	}
+}
+
+func Test_toQuantity_KindMismatch(t *testing.T) {
+	previous, err := toQuantity("10 msg/s", nil)
+	require.NoError(t, err)
+
+	_, err = toQuantity("3GB", &previous)
+	assert.EqualError(t, err, "cannot compute delta between 10 msg/s and 3.00 GB")
+}
+
+func Test_formatValueDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		current  string
+		want     string
+		wantErr  string
+	}{
+		{"integers", "10", "12", "+2", ""},
+		{"huge integers", "123456789012345678901234", "123456789012345678901230", "-4", ""},
+		{"integer then decimal", "2", "1.5", "-0.500", ""},
+		{"bytes then integer", "1 GiB", "1073741824", "", "cannot compute delta between 1.00 GiB and 1073741824"},
+		{"integer then bytes", "1", "2 GiB", "", "cannot compute delta between 1 and 2.00 GiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, err := toValue(tt.previous, nil)
+			require.NoError(t, err)
+
+			current, err := toValue(tt.current, &previous)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, formatValueDelta(current, previous))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package endian
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("endian", flag.ExitOnError)
+
+// Tool is endian runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "endian", Short: "Swaps the byte order of hexadecimal values and integers", Main: Main}
+
+var wordFlag = flags.Int("word", 0, "Swap the bytes of each word of this many bytes (2, 4, 8, 16 or 32) instead of the whole value")
+var intFlag = flags.Bool("int", false, "Input is an integer, swap its byte order on -width bytes (minimal width if not set) and print the resulting integer")
+var signedFlag = flags.Bool("signed", false, "With -int, decode the swapped bytes as a signed two's complement integer")
+
+var validWordSizes = []int{2, 4, 8, 16, 32}
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cli.Ensure(*wordFlag == 0 || slices.Contains(validWordSizes, *wordFlag), "Flag -word must be one of 2, 4, 8, 16 or 32")
+	cli.Ensure(!*intFlag || *wordFlag == 0, "Flag -word cannot be used with -int which swaps the whole -width")
+	cli.Ensure(*intFlag || (!*signedFlag && cli.NumberWidth() == 0), "Flags -signed and -width can only be used with -int")
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		if element == "" {
+			return "", "", nil
+		}
+
+		if *intFlag {
+			return swapInteger(element, cli.NumberWidth(), *signedFlag)
+		}
+
+		return swapHex(element, *wordFlag)
+	})
+}
+
+// swapHex reverses the bytes of the hexadecimal `element`, of each word of `wordSize` bytes
+// or of the whole value if 0, the `0x` prefix is kept if present
+func swapHex(element string, wordSize int) (string, string, error) {
+	prefix := ""
+	if strings.HasPrefix(element, "0x") || strings.HasPrefix(element, "0X") {
+		prefix = element[:2]
+	}
+
+	digits := element[len(prefix):]
+	if !cli.HexRegexp.MatchString(digits) {
+		return "", "", fmt.Errorf("value %q is not valid hexadecimal", element)
+	}
+
+	if len(digits)%2 != 0 {
+		return "", "", fmt.Errorf("value %q has an odd number of hexadecimal digits, it cannot be split in bytes", element)
+	}
+
+	bytes, err := cli.DecodeHex(digits)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid hexadecimal value %q: %w", element, err)
+	}
+
+	if err := swapBytes(bytes, wordSize); err != nil {
+		return "", "", err
+	}
+
+	return prefix + cli.EncodeHex(bytes), "hex", nil
+}
+
+// swapBytes reverses in place `bytes` or each of its words of `wordSize` bytes if not 0
+func swapBytes(bytes []byte, wordSize int) error {
+	if wordSize == 0 {
+		slices.Reverse(bytes)
+		return nil
+	}
+
+	if len(bytes)%wordSize != 0 {
+		return fmt.Errorf("value has %d bytes which is not a multiple of the %d bytes word size", len(bytes), wordSize)
+	}
+
+	for start := 0; start < len(bytes); start += wordSize {
+		slices.Reverse(bytes[start : start+wordSize])
+	}
+
+	return nil
+}
+
+// swapInteger encodes the integer `element` on `width` bytes, reverses them and decodes them
+// back as an integer, the value seen by the other endianness
+func swapInteger(element string, width int, signed bool) (string, string, error) {
+	value, err := cli.ParseNumber(element)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid integer %q: %w", element, err)
+	}
+
+	bytes, err := cli.NumberToBytes(value, width)
+	if err != nil {
+		return "", "", err
+	}
+
+	return cli.BytesToNumber(bytes, signed, true).String(), "integer", nil
+}
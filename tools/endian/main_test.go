@@ -0,0 +1,73 @@
+package endian
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_swapHex(t *testing.T) {
+	tests := []struct {
+		element  string
+		wordSize int
+		want     string
+		wantErr  string
+	}{
+		{"12345678", 0, "78563412", ""},
+		{"0x12345678", 0, "0x78563412", ""},
+		{"0X0102", 0, "0X0201", ""},
+		{"12345678", 2, "34127856", ""},
+		{"0011223344556677", 4, "3322110077665544", ""},
+		{"0011223344556677", 8, "7766554433221100", ""},
+
+		{"123", 0, "", `value "123" has an odd number of hexadecimal digits, it cannot be split in bytes`},
+		{"0xzz", 0, "", `value "0xzz" is not valid hexadecimal`},
+		{"1234567800", 4, "", "value has 5 bytes which is not a multiple of the 4 bytes word size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			got, _, err := swapHex(tt.element, tt.wordSize)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_swapInteger(t *testing.T) {
+	tests := []struct {
+		element string
+		width   int
+		signed  bool
+		want    string
+		wantErr string
+	}{
+		{"305419896", 4, false, "2018915346", ""},
+		{"0x12345678", 0, false, "2018915346", ""},
+		{"1", 8, false, "72057594037927936", ""},
+		{"255", 2, true, "-256", ""},
+		{"-2", 2, false, "65279", ""},
+
+		{"300", 1, false, "", "value 300 does not fit in 1 bytes"},
+		{"abc", 0, false, "", `invalid integer "abc"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			got, _, err := swapInteger(tt.element, tt.width, tt.signed)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,14 +1,13 @@
-package main
+package gobump
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
 	"regexp"
 	"strings"
 
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/tooling/cli"
 	"go.uber.org/zap"
-	"gopkg.in/yaml.v3"
 )
 
 type PackageID string
@@ -137,21 +136,38 @@ type AfterBump struct {
 	GoModTidy bool `yaml:"go_mod_tidy"`
 }
 
-func LoadConfig(file string) (*Config, error) {
-	zlog.Debug("trying to load config file", zap.String("file", file))
-	content, err := ioutil.ReadFile(file)
+var configLoader = &cli.ConfigLoader[Config]{
+	Tool:        "go_bump",
+	LegacyFiles: []string{".config/go_bump/default.yaml"},
+	Default:     newDefaultConfig,
+	Validate:    validateConfig,
+}
+
+func LoadConfig(cmd *cobra.Command) (*Config, error) {
+	loaded, err := configLoader.LoadFromCommand(cmd)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return newDefaultConfig(), nil
-		}
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("unable to read config file %q: %w", file, err)
+	zlog.Debug("loaded config", zap.String("file", loaded.File), zap.Bool("file_exists", loaded.FileExists))
+	return loaded.Config, nil
+}
+
+func validateConfig(config *Config) error {
+	for key, shortcut := range map[string]string{
+		"default_repo_shortcut":    config.DefaultRepoShortcut,
+		"default_project_shortcut": config.DefaultProjectShortcut,
+	} {
+		if strings.Contains(shortcut, "://") {
+			return fmt.Errorf("'%s' value %q must be a Go package path prefix like 'github.com/streamingfast', not an URL", key, shortcut)
+		}
 	}
 
-	config := newDefaultConfig()
-	err = yaml.Unmarshal(content, config)
+	if branch := config.DefaultBranchShortcut; branch != "" && !strings.HasPrefix(branch, "@") {
+		return fmt.Errorf("'default_branch_shortcut' value %q must start with '@' like '@develop'", branch)
+	}
 
-	return config, err
+	return nil
 }
 
 func newDefaultConfig() *Config {
@@ -1,4 +1,4 @@
-package main
+package goreplace
 
 import (
 	"fmt"
@@ -12,20 +12,31 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/streamingfast/cli"
 	. "github.com/streamingfast/cli"
+	toolingcli "github.com/streamingfast/tooling/cli"
 	"go.uber.org/zap"
 )
 
 var HookInstall = Command(hookInstall,
 	"install",
 	"Install Git hooks to ensure that you do not push a local replacement",
-	Flags(func(flags *pflag.FlagSet) {
-		flags.BoolP("dry-run", "n", true, "Perform a dry-run and do not actually perform the install")
-		flags.BoolP("force", "f", false, "Perform a real installation by writing hook(s) file into the repository")
-		flags.BoolP("overwrite", "o", false, "Overwrites existing file if found")
-		flags.String("skip", "", "Skip project matching flag's value (which is interpreted as a regex)")
-	}),
+	Flags(hookInstallFlags),
+	Description(`
+		Lists the hook(s) that would be written without writing them by default. Use -n=false to
+		write them after confirmation, --yes (or SFTOOL_ASSUME_YES=true) answering it, which is
+		required when standard input is not a terminal, or -f (--force) to write them without
+		confirmation. Use --confirm-timeout to bound the time given to answer, no hook is written
+		when it expires.
+	`),
 )
 
+func hookInstallFlags(flags *pflag.FlagSet) {
+	flags.BoolP("dry-run", "n", true, "Perform a dry-run and do not actually perform the install, use -n=false to write hook(s) after confirmation")
+	flags.BoolP("force", "f", false, "Perform a real installation by writing hook(s) file into the repository without asking for confirmation")
+	flags.BoolP("overwrite", "o", false, "Overwrites existing file if found")
+	flags.String("skip", "", "Skip project matching flag's value (which is interpreted as a regex)")
+	toolingcli.RegisterConfirmFlags(flags)
+}
+
 func hookInstall(cmd *cobra.Command, args []string) error {
 	root := "."
 	if len(args) == 1 {
@@ -34,7 +45,9 @@ func hookInstall(cmd *cobra.Command, args []string) error {
 
 	dryRun := getBoolFlag(cmd, "dry-run")
 	overwrite := getBoolFlag(cmd, "overwrite")
-	if getBoolFlag(cmd, "force") {
+	// Only an explicit flag leaves the dry-run, --yes (or SFTOOL_ASSUME_YES) answers the confirmation
+	force := getBoolFlag(cmd, "force")
+	if force {
 		dryRun = false
 	}
 	skip := getStringFlag(cmd, "skip")
@@ -78,18 +91,46 @@ func hookInstall(cmd *cobra.Command, args []string) error {
 	})
 	cli.NoError(err, "unable to complete walk of %q to find Git repositories", root)
 
+	var toHook []string
+	for _, gitRepository := range gitRepositories {
+		if hookIntoGitRepository(gitRepository, true, overwrite) {
+			toHook = append(toHook, gitRepository)
+		}
+	}
+
 	if dryRun {
-		fmt.Println("Dry run mode, use '-f' (--force) to write hooks")
+		if len(toHook) > 0 {
+			fmt.Println("Dry run mode, use '-f' (--force) to write hooks or '-n=false' to be asked for confirmation")
+		}
+		return nil
 	}
 
-	for _, gitRepository := range gitRepositories {
-		hookIntoGitRepository(gitRepository, dryRun, overwrite)
+	if len(toHook) == 0 {
+		return nil
+	}
+
+	if !force {
+		confirmed, err := toolingcli.Confirm(fmt.Sprintf("Write pre-push hook into %d Git repositories?", len(toHook)), toolingcli.WithConfirmDefault(false))
+		if err != nil {
+			return fmt.Errorf("confirm hooks install: %w", err)
+		}
+
+		if !confirmed {
+			fmt.Println("Aborted, no hook written, use --yes to write them without confirmation")
+			return nil
+		}
+	}
+
+	for _, gitRepository := range toHook {
+		hookIntoGitRepository(gitRepository, false, overwrite)
 	}
 
 	return nil
 }
 
-func hookIntoGitRepository(path string, dryRun bool, overwrite bool) {
+// hookIntoGitRepository writes the pre-push hook into the Git repository at `path`, or only
+// prints what would be done if `dryRun` is set, returning true if the hook is (or would be) written
+func hookIntoGitRepository(path string, dryRun bool, overwrite bool) bool {
 	zlog.Debug("hooking into Git repository, if contains some 'go.mod' files")
 	directMatches, err := filepath.Glob(filepath.Join(path, "go.mod"))
 	cli.NoError(err, "unable to check if git repository has some 'go.mod' files")
@@ -99,7 +140,7 @@ func hookIntoGitRepository(path string, dryRun bool, overwrite bool) {
 
 	if len(directMatches)+len(subMatches) == 0 {
 		zlog.Debug("git repository does not seems like a Golang project (no go.mod files), skipping", zap.String("git_repository", path))
-		return
+		return false
 	}
 
 	gitDir := filepath.Join(path, ".git")
@@ -110,18 +151,19 @@ func hookIntoGitRepository(path string, dryRun bool, overwrite bool) {
 	if dryRun {
 		if alreadyExists && !overwrite {
 			fmt.Printf("Would NOT overwrite existing pre-push hook at %s, use -o (--overwite) to overwrite it\n", prePushHookFile)
+			return false
 		} else if alreadyExists && overwrite {
 			fmt.Printf("Would overwrite existing pre-push at %s\n", prePushHookFile)
 		} else {
 			fmt.Printf("Would write pre-push hook to %s\n", prePushHookFile)
 		}
 
-		return
+		return true
 	}
 
 	if alreadyExists && !overwrite {
 		printlnError("A pre-push hook already exists at %s but flag -o (--overwrite) was not passed to overwrite it", prePushHookFile)
-		return
+		return false
 	}
 
 	prePushContent := fmt.Sprintf(prePushContentTemplate, "v0.0.1", time.Now().Format(time.Kitchen))
@@ -129,6 +171,7 @@ func hookIntoGitRepository(path string, dryRun bool, overwrite bool) {
 	cli.NoError(os.MkdirAll(hooksDir, os.ModePerm), "unable to create %q directory", hooksDir)
 	cli.NoError(os.WriteFile(prePushHookFile, []byte(prePushContent), os.ModePerm), "unable to write pre-push hook")
 	fmt.Printf("Wrote pre-push hook to %s\n", prePushHookFile)
+	return true
 }
 
 var prePushContentTemplate = `#!/bin/sh
@@ -0,0 +1,25 @@
+package goreplace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	toolingcli "github.com/streamingfast/tooling/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hookInstall_AssumeYesKeepsDryRun(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "project", ".git", "hooks"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "project", "go.mod"), []byte("module project\n"), 0644))
+
+	t.Setenv(toolingcli.AssumeYesEnv, "1")
+	cmd := &cobra.Command{}
+	hookInstallFlags(cmd.Flags())
+
+	require.NoError(t, hookInstall(cmd, []string{root}))
+	assert.NoFileExists(t, filepath.Join(root, "project", ".git", "hooks", "pre-push"))
+}
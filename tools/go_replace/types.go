@@ -1,16 +1,16 @@
-package main
+package goreplace
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
+	toolingcli "github.com/streamingfast/tooling/cli"
 	"go.uber.org/zap"
-	"gopkg.in/yaml.v3"
 )
 
 type Replacement struct {
@@ -88,6 +88,10 @@ func looksLikeRelativePath(dep string) bool {
 }
 
 func resolveWorkingDirPath(workingDir string, from string) (string, error) {
+	if !cli.DirectoryExists(workingDir) {
+		return "", fmt.Errorf("'default_work_dir' directory %q does not exist", workingDir)
+	}
+
 	path := filepath.Join(workingDir, from)
 	abs, err := filepath.Abs(path)
 	if err != nil {
@@ -131,24 +135,42 @@ type Config struct {
 	DefaultProjectShortcut string `yaml:"default_project_shortcut"`
 }
 
-func LoadConfig(file string) (*Config, error) {
-	zlog.Debug("trying to load config file", zap.String("file", file))
-	content, err := ioutil.ReadFile(file)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return newDefaultConfig(), nil
-		}
+var configLoader = &toolingcli.ConfigLoader[Config]{
+	Tool:        "go_replace",
+	LegacyFiles: []string{".config/go_replace/default.yaml"},
+	Default:     newDefaultConfig,
+	Validate:    validateConfig,
+}
 
-		return nil, fmt.Errorf("unable to read config file %q: %w", file, err)
+func LoadConfig(cmd *cobra.Command) (*Config, error) {
+	loaded, err := configLoader.LoadFromCommand(cmd)
+	if err != nil {
+		return nil, err
 	}
 
-	config := newDefaultConfig()
-	err = yaml.Unmarshal(content, config)
-	if err == nil && config.DefaultWorkDir != "" {
+	zlog.Debug("loaded config", zap.String("file", loaded.File), zap.Bool("file_exists", loaded.FileExists))
+
+	config := loaded.Config
+	if config.DefaultWorkDir != "" {
 		config.DefaultWorkDir = os.ExpandEnv(config.DefaultWorkDir)
 	}
 
-	return config, err
+	return config, nil
+}
+
+func validateConfig(config *Config) error {
+	// A missing 'default_work_dir' is only reported when it's used, see resolveWorkingDirPath, so that
+	// the commands not needing it (e.g. 'hook install' or relative replacements) keep working
+	for key, shortcut := range map[string]string{
+		"default_repo_shortcut":    config.DefaultRepoShortcut,
+		"default_project_shortcut": config.DefaultProjectShortcut,
+	} {
+		if strings.Contains(shortcut, "://") {
+			return fmt.Errorf("'%s' value %q must be a Go package path prefix like 'github.com/streamingfast', not an URL", key, shortcut)
+		}
+	}
+
+	return nil
 }
 
 func newDefaultConfig() *Config {
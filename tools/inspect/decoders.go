@@ -0,0 +1,306 @@
+package inspect
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/streamingfast/tooling/cli"
+)
+
+// interpretation is one plausible decoding of an inspected value, Score goes from 0 (matches
+// the alphabet but is most probably not it) to 100 (almost certainly it).
+type interpretation struct {
+	Encoding string
+	Score    int
+	Decoded  string
+	Notes    []string
+}
+
+func (i *interpretation) adjust(delta int, note string, args ...any) {
+	i.Score += delta
+	i.Notes = append(i.Notes, fmt.Sprintf(note, args...))
+}
+
+// decoder returns the interpretation of `element` in its encoding, nil if `element` cannot
+// be decoded that way
+type decoder func(element string, timezone *time.Location) *interpretation
+
+// decoders are tried in order, which is also the ranking of interpretations scoring the same
+var decoders = []decoder{
+	decodeBech32,
+	decodeDate,
+	decodeHex,
+	decodeDecimal,
+	decodeBase58,
+	decodeBase64,
+	decodeEOSName,
+}
+
+// sane timestamps range, Unix timestamps outside of it are most probably not timestamps
+var minSaneDate = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+var maxSaneDate = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// commonByteLengths are the lengths of usual hashes, addresses and keys
+var commonByteLengths = map[int]bool{20: true, 32: true, 33: true, 64: true, 65: true}
+
+var base58Regexp = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]+$`)
+var eosNameRegexp = regexp.MustCompile(`^[a-z1-5.]{1,12}[a-j1-5.]?$`)
+
+// inspect returns all the interpretations of `element`, highest score first
+func inspect(element string, timezone *time.Location) (out []*interpretation) {
+	element = strings.TrimSpace(element)
+	if element == "" {
+		return nil
+	}
+
+	for _, decoder := range decoders {
+		if interpretation := decoder(element, timezone); interpretation != nil {
+			interpretation.Score = max(0, min(100, interpretation.Score))
+			out = append(out, interpretation)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
+
+func decodeHex(element string, _ *time.Location) *interpretation {
+	if !cli.HexRegexp.MatchString(element) {
+		return nil
+	}
+
+	bytes, err := cli.DecodeHex(element)
+	if err != nil {
+		return nil
+	}
+
+	out := &interpretation{Encoding: "hex", Score: 40, Decoded: previewBytes(bytes)}
+	digits := strings.TrimPrefix(strings.TrimPrefix(element, "0x"), "0X")
+
+	if len(digits) != len(element) {
+		out.adjust(+40, "0x prefix")
+	} else if len(digits)%2 != 0 {
+		out.adjust(-20, "odd length")
+	}
+
+	hasLower, hasUpper := strings.ContainsAny(digits, "abcdef"), strings.ContainsAny(digits, "ABCDEF")
+	switch {
+	case hasLower && hasUpper:
+		out.adjust(-20, "mixed case")
+	case !hasLower && !hasUpper:
+		out.adjust(-20, "digits only")
+	default:
+		out.adjust(+10, "hex letters")
+	}
+
+	scoreBytes(out, bytes)
+	return out
+}
+
+func decodeDecimal(element string, _ *time.Location) *interpretation {
+	if !cli.DecRegexp.MatchString(element) {
+		return nil
+	}
+
+	value, ok := new(big.Int).SetString(element, 10)
+	if !ok {
+		return nil
+	}
+
+	out := &interpretation{Encoding: "decimal", Score: 45, Decoded: "0x" + cli.EncodeHex(value.Bytes())}
+	if len(element) > 1 && element[0] == '0' {
+		out.adjust(-30, "leading zero")
+	}
+
+	return out
+}
+
+func decodeDate(element string, timezone *time.Location) *interpretation {
+	date, trace, ok := cli.ExplainDateLikeInput(element, cli.DateLikeHintNone, timezone)
+	if !ok {
+		return nil
+	}
+
+	out := &interpretation{Encoding: "date", Decoded: formatDate(date)}
+	if trace.ParsedFrom != cli.DateParsedFromTimestamp {
+		out.adjust(+90, "parsed from %s", strings.ToLower(trace.ParsedFrom.String()))
+		return out
+	}
+
+	out.Encoding = "timestamp"
+	if date.Before(minSaneDate) || !date.Before(maxSaneDate) {
+		out.adjust(+5, "Unix %s outside %d-%d", trace.EpochUnit, minSaneDate.Year(), maxSaneDate.Year())
+		return out
+	}
+
+	out.adjust(+60, "Unix %s within %d-%d", trace.EpochUnit, minSaneDate.Year(), maxSaneDate.Year())
+	return out
+}
+
+func decodeBech32(element string, _ *time.Location) *interpretation {
+	hrp, data, variant, err := cli.DecodeBech32(element, "")
+	if err != nil {
+		return nil
+	}
+
+	encoding := "bech32"
+	if variant == cli.Bech32VariantBech32m {
+		encoding = "bech32m"
+	}
+
+	if version, program, err := cli.DecodeSegWit(element, ""); err == nil {
+		out := &interpretation{Encoding: "segwit", Decoded: fmt.Sprintf("hrp %s, witness v%d, %s", hrp, version, previewBytes(program))}
+		out.adjust(+100, "valid %s checksum and witness program", encoding)
+
+		return out
+	}
+
+	bytes, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil
+	}
+
+	out := &interpretation{Encoding: encoding, Decoded: fmt.Sprintf("hrp %s, %s", hrp, previewBytes(bytes))}
+	out.adjust(+95, "valid checksum")
+
+	return out
+}
+
+func decodeBase58(element string, _ *time.Location) *interpretation {
+	if !base58Regexp.MatchString(element) {
+		return nil
+	}
+
+	bytes, err := cli.MustLookupCodec("base58").Decode(element)
+	if err != nil || len(bytes) == 0 {
+		return nil
+	}
+
+	out := &interpretation{Encoding: "base58", Score: 30, Decoded: previewBytes(bytes)}
+	if payload, err := cli.DecodeBase58Check(element, nil); err == nil && len(payload) > 0 {
+		out.Encoding = "base58check"
+		out.Decoded = fmt.Sprintf("version %d, %s", payload[0], previewBytes(payload[1:]))
+		out.adjust(+60, "valid checksum")
+
+		return out
+	}
+
+	if cli.DecRegexp.MatchString(element) {
+		out.adjust(-25, "digits only")
+	}
+
+	scoreBytes(out, bytes)
+	return out
+}
+
+func decodeBase64(element string, _ *time.Location) *interpretation {
+	encoding, name, alphabet := base64.StdEncoding, "base64", "+/"
+	if strings.ContainsAny(element, "-_") {
+		encoding, name, alphabet = base64.URLEncoding, "base64url", "-_"
+	}
+
+	if !cli.Base64StdRegexp.MatchString(element) && !cli.Base64URLRegexp.MatchString(element) {
+		return nil
+	}
+
+	padded := strings.HasSuffix(element, "=")
+	if !padded && len(element)%4 != 0 {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+
+	bytes, err := encoding.DecodeString(element)
+	if err != nil || len(bytes) == 0 {
+		return nil
+	}
+
+	out := &interpretation{Encoding: name, Score: 30, Decoded: previewBytes(bytes)}
+	if strings.ContainsAny(element, alphabet) {
+		out.adjust(+25, "%s characters", alphabet)
+	}
+
+	if padded {
+		out.adjust(+20, "padding")
+	} else if len(element)%4 != 0 {
+		out.adjust(-10, "unpadded")
+	}
+
+	if cli.HexRegexp.MatchString(element) {
+		out.adjust(-25, "hex characters only")
+	}
+
+	scoreBytes(out, bytes)
+	return out
+}
+
+func decodeEOSName(element string, _ *time.Location) *interpretation {
+	if !eosNameRegexp.MatchString(element) {
+		return nil
+	}
+
+	value, err := eos.StringToName(element)
+	if err != nil || eos.NameToString(value) != element {
+		return nil
+	}
+
+	out := &interpretation{Encoding: "eos name", Score: 25, Decoded: fmt.Sprintf("%d (0x%016x)", value, value)}
+	if strings.Contains(element, ".") {
+		out.adjust(+25, "dotted name")
+	}
+
+	if cli.DecRegexp.MatchString(element) {
+		out.adjust(-20, "digits only")
+	}
+
+	return out
+}
+
+// scoreBytes adjusts the score of `out` based on how the decoded `bytes` look like
+func scoreBytes(out *interpretation, bytes []byte) {
+	if len(bytes) > 1 && printableRatio(bytes) >= 0.9 {
+		out.adjust(+15, "printable text")
+	}
+
+	if commonByteLengths[len(bytes)] {
+		out.adjust(+10, "%d bytes", len(bytes))
+	}
+}
+
+func printableRatio(bytes []byte) float64 {
+	if len(bytes) == 0 {
+		return 0
+	}
+
+	printable := 0
+	for _, b := range bytes {
+		if (b >= 0x20 && b < 0x7f) || b == '\t' || b == '\n' || b == '\r' {
+			printable++
+		}
+	}
+
+	return float64(printable) / float64(len(bytes))
+}
+
+const maxPreviewLength = 48
+
+// previewBytes renders `bytes` as a quoted string if mostly printable, in hexadecimal otherwise,
+// truncated to [maxPreviewLength] characters
+func previewBytes(bytes []byte) string {
+	preview := "0x" + cli.EncodeHex(bytes)
+	if len(bytes) > 1 && printableRatio(bytes) >= 0.9 {
+		preview = strconv.Quote(string(bytes))
+	}
+
+	if len(preview) > maxPreviewLength {
+		preview = preview[:maxPreviewLength-3] + "..."
+	}
+
+	return fmt.Sprintf("%s (%d bytes)", preview, len(bytes))
+}
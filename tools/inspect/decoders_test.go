@@ -0,0 +1,48 @@
+package inspect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_inspect(t *testing.T) {
+	tests := []struct {
+		element   string
+		want      []string
+		wantFirst string
+	}{
+		{"0xdeadbeef", []string{"hex", "base64"}, "hex 0xdeadbeef (4 bytes)"},
+		{"deadbeef", []string{"hex", "base58", "eos name", "base64"}, "hex 0xdeadbeef (4 bytes)"},
+		{"68656c6c6f", []string{"hex", "base58", "base64"}, `hex "hello" (5 bytes)`},
+		{"1700000000", []string{"timestamp", "decimal", "hex", "base64"}, "timestamp 2023-11-14T22:13:20Z"},
+		{"99999999999999999999", []string{"decimal", "hex", "base58", "base64"}, "decimal 0x056bc75e2d630fffff"},
+		{"aGVsbG8gd29ybGQ=", []string{"base64"}, `base64 "hello world" (11 bytes)`},
+		{"SGVsbG8_", []string{"base64url"}, `base64url "Hello?" (6 bytes)`},
+		{"eosio.token", []string{"eos name"}, "eos name 6138663591592764928 (0x5530ea033482a600)"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", []string{"base58check", "base64"}, "base58check version 5, 0xb472a266d0bd89c13706a4132ccfb16f7c3b9fcb (20 bytes)"},
+		{"cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", []string{"bech32"}, "bech32 hrp cosmos, 0x0102030405060708090a0b0c0d0e0f1011121314 (20 bytes)"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", []string{"segwit", "base64"}, "segwit hrp bc, witness v1, 0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dc... (32 bytes)"},
+		{"bc1qypqn9pfur", []string{"bech32m", "base58"}, "bech32m hrp bc, 0x0102 (2 bytes)"},
+		{"2024-01-02T15:04:05Z", []string{"date"}, "date 2024-01-02T15:04:05Z"},
+		{"@@", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			interpretations := inspect(tt.element, time.UTC)
+
+			var encodings []string
+			for _, interpretation := range interpretations {
+				assert.True(t, interpretation.Score >= 0 && interpretation.Score <= 100, "score %d of %s out of range", interpretation.Score, interpretation.Encoding)
+				encodings = append(encodings, interpretation.Encoding)
+			}
+
+			assert.Equal(t, tt.want, encodings)
+			if tt.wantFirst != "" {
+				assert.Equal(t, tt.wantFirst, interpretations[0].Encoding+" "+interpretations[0].Decoded)
+			}
+		})
+	}
+}
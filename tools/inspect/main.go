@@ -0,0 +1,108 @@
+package inspect
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("inspect", flag.ExitOnError)
+
+// Tool is inspect runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "inspect", Short: "Tries every known decoding of a value and ranks the interpretations", Main: Main}
+
+var allFlag = flags.Bool("all", false, "Also list the interpretations scoring 0, which are hidden by default")
+var timezoneFlag = flags.String("timezone", "local", "When the value is a date without timezone information, use this timezone to interpret it. Valid values are 'local', 'utc', 'z' or a valid timezone name.")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	timezone, err := cli.ParseTimezone(*timezoneFlag)
+	cli.NoError(err, "invalid timezone provided")
+
+	emitter := cli.NewEmitter()
+
+	count := 0
+	scanner := cli.NewArgumentScanner(flags.Args())
+	for element, ok, err := cli.ScanElement(scanner); ok; element, ok, err = cli.ScanElement(scanner) {
+		count++
+		if err != nil {
+			emitter.Emit(cli.Result{Input: element, Error: err, Line: cli.ElementLine(scanner)})
+			continue
+		}
+
+		interpretations := inspect(element, timezone)
+		if !*allFlag {
+			interpretations = plausibleOnly(interpretations)
+		}
+
+		if len(interpretations) == 0 {
+			emitter.Emit(cli.Result{Input: element, Error: fmt.Errorf("no known decoding matches %q", element), Line: cli.ElementLine(scanner)})
+			continue
+		}
+
+		if emitter.IsText() {
+			out := renderTable(element, interpretations)
+			if count > 1 {
+				out = "\n" + out
+			}
+
+			emitter.Emit(cli.Result{Input: element, Output: out, Kind: interpretations[0].Encoding})
+			continue
+		}
+
+		// Structured formats get one record per interpretation, best one first
+		results := make([]cli.Result, len(interpretations))
+		for i, interpretation := range interpretations {
+			results[i] = cli.Result{
+				Input:   element,
+				Output:  interpretation.Decoded,
+				Kind:    interpretation.Encoding,
+				Score:   &interpretation.Score,
+				Explain: interpretation.Notes,
+			}
+		}
+		emitter.EmitAll(results)
+	}
+
+	emitter.Close()
+	emitter.Errors().ExitOnFailures(count)
+}
+
+func plausibleOnly(interpretations []*interpretation) (out []*interpretation) {
+	for _, interpretation := range interpretations {
+		if interpretation.Score > 0 {
+			out = append(out, interpretation)
+		}
+	}
+
+	return out
+}
+
+func renderTable(element string, interpretations []*interpretation) string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "%s\n", element)
+
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "RANK\tSCORE\tENCODING\tDECODED\tNOTES")
+	for i, interpretation := range interpretations {
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\n", i+1, interpretation.Score, interpretation.Encoding, interpretation.Decoded, strings.Join(interpretation.Notes, ", "))
+	}
+	writer.Flush()
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func formatDate(in time.Time) string {
+	if _, offset := in.Zone(); offset == 0 {
+		return in.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprintf("%s (%s)", in.Format(time.RFC3339Nano), in.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,146 @@
+package kcctx
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"path/filepath"
+	"strings"
+
+	toolingcli "github.com/streamingfast/tooling/cli"
+)
+
+// Tool is kcctx runnable as its own binary or as a subcommand of `sftool`.
+var Tool = toolingcli.Tool{Name: "kcctx", Short: "Manages to which Kubernetes cluster/namespace your environment works with", Main: Main}
+
+var zlog, _ = logging.PackageLogger("kcctx", "github.com/streamingfast/tooling/tools/kcctx")
+
+func Main() {
+	logging.InstantiateLoggers(logging.WithDefaultLevel(zap.ErrorLevel))
+
+	Run(
+		"kcctx [-g] [<cluster>@]<namespace>",
+		"Manages to which cluster/namespace your environment works with locally or globally",
+		Description(`
+			Generates a kube config file for <namespace> of <cluster> (or of 'default_cluster') out of
+			'~/.kube/master.config' and exports KUBECONFIG pointing to it.
+
+			The config file is '$HOME/.config/streamingfast/tooling/kcctx.yaml' (the legacy
+			'$HOME/.config/kcctx/config.yaml' is still read if the former does not exist):
+
+			  default_cluster: dev
+			  clusters:
+			    dev:
+			      user: dev-user
+			    prod:
+			      name: gke_project_region_prod   # Cluster name in master.config when it differs from the key
+			      user: prod-user
+
+			Use 'kcctx config show' to see the effective configuration. A namespace named 'config'
+			must be given after '--' (i.e. 'kcctx -- config') to not run the config commands.
+		`),
+		ExactArgs(1),
+		PersistentFlags(func(flags *pflag.FlagSet) {
+			flags.BoolP("global", "g", false, "The environment changes will apply globally to your system affecting the other terminals or new ones created")
+		}),
+		AfterAllHook(func(cmd *cobra.Command) {
+			cli.ConfigureViperForCommand(cmd, "KCCTX")
+		}),
+		Example(`
+			# Configure your environment to use 'eth-mainnet' namespace on <default_cluster>
+			$(kcctx eth-mainnet)
+		`),
+		Execute(execute),
+		configLoader.Group(),
+	)
+}
+
+func execute(cmd *cobra.Command, args []string) error {
+	// FIXME: Ensure only of local or global flag is set
+
+	input, err := ParseInput(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid argument %q: %w", args[0], err)
+	}
+
+	loaded, err := configLoader.LoadFromCommand(cmd)
+	if err != nil {
+		return fmt.Errorf("unable to load config: %w", err)
+	}
+
+	config := loaded.Config
+	zlog.Info("config loaded", zap.String("file", loaded.File), zap.Reflect("config", config))
+
+	if input.Cluster == "" && config.DefaultCluster == "" {
+		return fmt.Errorf(`cannot use "<namespace>" invocation because "default_cluster" is not set in %q, use "<cluster>@<namespace>"`, loaded.File)
+	}
+
+	kubeConfig, err := generateKubeConfig(config, input)
+	if err != nil {
+		return fmt.Errorf("generate kube config: %w", err)
+	}
+
+	kubeConfigDirectory, err := DefaultKubeConfigDirectoryLocation()
+	if err != nil {
+		return fmt.Errorf("unable to determine kube config directory: %w", err)
+	}
+
+	kubeMasterConfig, err := ParseKubeMasterConfig(kubeConfigDirectory)
+	if err != nil {
+		return fmt.Errorf("parse kube master config: %w", err)
+	}
+
+	kubeMasterConfig.SetActiveContext(kubeConfig.Name)
+	kubeMasterConfig.SetContexts(kubeConfig)
+	kubeMasterConfig.KeepOnlyClusterWithNameIn(kubeConfig.Context.Cluster)
+	kubeMasterConfig.KeepOnlyUserWithNameIn(kubeConfig.Context.User)
+
+	kubeConfigFile := filepath.Join(kubeConfigDirectory, "config-"+strings.ReplaceAll(kubeConfig.Name, "/", "-"))
+	if viper.GetBool("global-global") {
+		kubeConfigFile = filepath.Join(kubeConfigDirectory, "config")
+	}
+
+	zlog.Debug("storing kube context", zap.String("path", kubeConfigFile))
+	if err := kubeMasterConfig.WriteTo(kubeConfigFile); err != nil {
+		return fmt.Errorf("unable to write kube config file: %w", err)
+	}
+
+	fmt.Printf("export KUBECONFIG=%s\n", kubeConfigFile)
+	fmt.Printf("export BULLETTRAIN_KCTX_KCONFIG=%s\n", kubeConfigFile)
+
+	return nil
+}
+
+func generateKubeConfig(config *Config, input *Input) (*KubeConfig, error) {
+	cluster := config.DefaultCluster
+	if input.Cluster != "" {
+		cluster = input.Cluster
+	}
+
+	clusterSpec := config.FindClusterSpec(cluster)
+	if clusterSpec == nil {
+		return nil, fmt.Errorf("no cluster named %q found in your config", cluster)
+	}
+
+	if clusterSpec.User == "" {
+		return nil, fmt.Errorf("cluster spec named %q does not have a configured user associated with", cluster)
+	}
+	kubeCluster := cluster
+	if clusterSpec.Name != "" {
+		kubeCluster = clusterSpec.Name
+	}
+
+	return &KubeConfig{
+		Name: fmt.Sprintf("%s/%s", cluster, input.Namespace),
+		Context: &KubeContext{
+			Cluster:   kubeCluster,
+			Namespace: input.Namespace,
+			User:      clusterSpec.User,
+		},
+	}, nil
+}
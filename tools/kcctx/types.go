@@ -1,13 +1,14 @@
-package main
+package kcctx
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	toolingcli "github.com/streamingfast/tooling/cli"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 )
@@ -146,30 +147,25 @@ type ClusterSpec struct {
 	User string `yaml:"user"`
 }
 
-func LoadConfig(file string) (*Config, error) {
-	zlog.Debug("trying to load config file", zap.String("file", file))
-	content, err := ioutil.ReadFile(file)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return newDefaultConfig(), nil
-		}
+var configLoader = &toolingcli.ConfigLoader[Config]{
+	Tool:        "kcctx",
+	LegacyFiles: []string{".config/kcctx/config.yaml"},
+	Default:     newDefaultConfig,
+	Validate:    validateConfig,
+}
 
-		return nil, fmt.Errorf("unable to read config file %q: %w", file, err)
+func validateConfig(config *Config) error {
+	if config.DefaultCluster != "" && config.FindClusterSpec(config.DefaultCluster) == nil {
+		return fmt.Errorf("'default_cluster' value %q is not one of the configured 'clusters' [%s]", config.DefaultCluster, strings.Join(maps.Keys(config.Clusters), ", "))
 	}
 
-	config := newDefaultConfig()
-	err = yaml.Unmarshal(content, config)
-
-	return config, err
-}
-
-func DefaultConfigLocation() (string, error) {
-	userHome, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("unable to determine user directory: %w", err)
+	for name, spec := range config.Clusters {
+		if spec == nil || spec.User == "" {
+			return fmt.Errorf("cluster %q must define a 'user'", name)
+		}
 	}
 
-	return filepath.Join(userHome, ".config", "kcctx", "config.yaml"), nil
+	return nil
 }
 
 func newDefaultConfig() *Config {
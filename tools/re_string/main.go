@@ -0,0 +1,52 @@
+package restring
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/tooling/cli"
+)
+
+// Tool is re_string runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "re_string", Short: "Turns literal '\\n' and '\\t' into their character representation", Main: Main}
+
+func Main() {
+	Run(
+		"re_string",
+		"Turns a string containing literal '\n' and '\t' into their character representation",
+		Description(`
+			The goal of this command is to "decode" raw string escape sequences into their
+			actual representation so in essence, it's kind of a reformat of a "raw" string.
+		`),
+		Example(`
+			# Would print
+			#
+			#   first string
+			#       second string
+			#
+			re_string "first string\n\tsecond string"
+
+			# Works also with piping
+			pbpaste | re_string
+		`),
+		Flags(func(flags *pflag.FlagSet) {
+			cli.RegisterConverterFlags(flags)
+		}),
+		Execute(func(_ *cobra.Command, args []string) error {
+			cli.ConvertArguments(cli.NewArgumentScanner(args), func(element string) (string, string, error) {
+				return reString(element), "", nil
+			})
+
+			return nil
+		}),
+	)
+}
+
+func reString(in string) string {
+	in = strings.ReplaceAll(in, "\\n", "\n")
+	in = strings.ReplaceAll(in, "\\t", "\t")
+
+	return in
+}
@@ -1,4 +1,4 @@
-package main
+package sfenv
 
 import (
 	"github.com/golang-jwt/jwt/v5"
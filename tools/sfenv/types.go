@@ -1,4 +1,4 @@
-package main
+package sfenv
 
 import (
 	"crypto/sha256"
@@ -10,8 +10,9 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/spf13/cobra"
+	toolingcli "github.com/streamingfast/tooling/cli"
 	"go.uber.org/zap"
-	"gopkg.in/yaml.v3"
 )
 
 type Input struct {
@@ -78,23 +79,26 @@ func (n *Network) fromConfig(config *networkConfigDef) *Network {
 	return n
 }
 
-func LoadConfig(file string) (*Config, error) {
-	zlog.Debug("trying to load config file", zap.String("file", file))
-	content, err := os.ReadFile(file)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return newDefaultConfig(), nil
-		}
-
-		return nil, fmt.Errorf("unable to read config file %q: %w", file, err)
-	}
+var configLoader = &toolingcli.ConfigLoader[config]{
+	Tool:        "sfenv",
+	LegacyFiles: []string{".config/sfenv/config.yaml"},
+	Validate: func(parsed *config) error {
+		_, err := newConfig(parsed)
+		return err
+	},
+}
 
-	parsed := &config{}
-	err = yaml.Unmarshal(content, parsed)
+func LoadConfig(cmd *cobra.Command) (*Config, error) {
+	loaded, err := configLoader.LoadFromCommand(cmd)
 	if err != nil {
-		return nil, fmt.Errorf("unable to unmarshal config file %q: %w", file, err)
+		return nil, err
 	}
 
+	zlog.Debug("loaded config", zap.String("file", loaded.File), zap.Bool("file_exists", loaded.FileExists))
+	return newConfig(loaded.Config)
+}
+
+func newConfig(parsed *config) (*Config, error) {
 	config := &Config{
 		ApiKeys:         make([]*ApiKey, 0, len(parsed.ApiKeys)),
 		ApiKeysByName:   map[string]*ApiKey{},
@@ -177,7 +181,7 @@ func LoadConfig(file string) (*Config, error) {
 		config.NetworksByName["default"] = defaultNetwork
 	}
 
-	return config, err
+	return config, nil
 }
 
 type config struct {
@@ -192,15 +196,6 @@ type networkConfigDef struct {
 	JWTIssuerBaseURL *string  `yaml:"jwtIssuerBaseUrl"`
 }
 
-func DefaultConfigLocation() (string, error) {
-	userHome, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("unable to determine user directory: %w", err)
-	}
-
-	return filepath.Join(userHome, ".config", "sfenv", "config.yaml"), nil
-}
-
 func DefaultJWTCacheLocation() (string, error) {
 	userHome, err := os.UserHomeDir()
 	if err != nil {
@@ -209,15 +204,3 @@ func DefaultJWTCacheLocation() (string, error) {
 
 	return filepath.Join(userHome, ".config", "sfenv", "jwt-cache"), nil
 }
-
-func newDefaultConfig() *Config {
-	return &Config{
-		ApiKeys:        []*ApiKey{},
-		ApiKeysByName:  map[string]*ApiKey{},
-		Networks:       []*Network{},
-		NetworksByName: map[string]*Network{},
-
-		DefaultApiKey:  nil,
-		DefaultNetwork: nil,
-	}
-}
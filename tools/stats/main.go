@@ -0,0 +1,327 @@
+package stats
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("stats", flag.ExitOnError)
+
+// Tool is stats runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "stats", Short: "Computes statistics about numbers received", Main: Main}
+
+var unit = flags.String("u", "", "An optional unit value, appended verbatim to each element of the final report if present")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	elementCount := uint64(0)
+	sum := 0.0
+	var min, max *float64
+	var distribution []float64
+
+	scanner := cli.NewArgumentScanner(flags.Args())
+	emitter := cli.NewEmitter()
+	onError := emitter.Errors()
+	currentValueKind := (*ValueKind)(nil)
+
+	line := 0
+	for element, ok, err := cli.ScanElement(scanner); ok; element, ok, err = cli.ScanElement(scanner) {
+		line++
+
+		var value float64
+		var valueKind ValueKind
+		if err == nil {
+			value, valueKind, err = parse(element, currentValueKind)
+		}
+		if err == nil && currentValueKind != nil && *currentValueKind != valueKind {
+			err = fmt.Errorf("all arguments should be of the same kind, %s and %s are not", *currentValueKind, valueKind)
+		}
+
+		if err != nil {
+			// There is nothing to pass through when computing statistics, the element is skipped
+			onError.Handle(cli.ElementPosition{Index: line, Line: cli.ElementLine(scanner)}, element, err)
+			continue
+		}
+
+		if currentValueKind == nil {
+			currentValueKind = &valueKind
+		}
+
+		distribution = append(distribution, value)
+
+		elementCount++
+		sum += value
+		if min == nil || *min > value {
+			min = &value
+		}
+
+		if max == nil || *max < value {
+			max = &value
+		}
+	}
+
+	if elementCount == 0 {
+		if emitter.IsText() {
+			emitter.Emit(cli.Result{Output: "Statistics unavailable, no data"})
+		}
+
+		emitter.Close()
+		onError.ExitOnFailures(line)
+		return
+	}
+
+	sort.Float64Slice(distribution).Sort()
+
+	format := formatIntOrFloat
+	switch *currentValueKind {
+	case ValueKindDuration:
+		*unit = ""
+		format = func(value float64) string { return duration(value).String() }
+	case ValueKindBytes:
+		format = func(value float64) string { return bytes(value).String() }
+	case ValueKindRate:
+		format = func(value float64) string {
+			return cli.Quantity{Kind: cli.QuantityKindRate, Value: value, Binary: detectedBase == nil || *detectedBase, RateUnit: *currentRateUnit}.String()
+		}
+	}
+
+	mean := sum / float64(elementCount)
+	statistics := []statistic{
+		{"count", count(elementCount).String()},
+		{"min", format(*min)},
+		{"max", format(*max)},
+		{"sum", format(sum)},
+		{"average", format(mean)},
+		{"median", format(percentile(distribution, 50))},
+		{"p90", format(percentile(distribution, 90))},
+		{"p95", format(percentile(distribution, 95))},
+		{"p99", format(percentile(distribution, 99))},
+		{"standard_deviation", format(standardDeviation(mean, distribution))},
+	}
+
+	if emitter.IsText() {
+		for _, line := range reportLines(statistics) {
+			emitter.Emit(cli.Result{Output: line})
+		}
+	} else {
+		for _, statistic := range statistics {
+			emitter.Emit(cli.Result{Output: statistic.value, Kind: statistic.kind})
+		}
+	}
+
+	emitter.Close()
+	onError.ExitOnFailures(line)
+}
+
+// statistic is a single value of the report, its kind being the name of the statistic
+type statistic struct {
+	kind  string
+	value string
+}
+
+// reportLines renders statistics as the human readable report
+func reportLines(statistics []statistic) []string {
+	values := make(map[string]string, len(statistics))
+	for _, statistic := range statistics {
+		values[statistic.kind] = statistic.value
+	}
+
+	return []string{
+		fmt.Sprintf("Count: %s", values["count"]),
+		fmt.Sprintf("Range: Min %s - Max %s", values["min"], values["max"]),
+		fmt.Sprintf("Sum: %s", values["sum"]),
+		fmt.Sprintf("Average: %s", values["average"]),
+		fmt.Sprintf("Median: %s (p90=%s p95=%s p99=%s)", values["median"], values["p90"], values["p95"], values["p99"]),
+		fmt.Sprintf("Standard Deviation: %s", values["standard_deviation"]),
+	}
+}
+
+var detectedBase *bool // nil = not detected, true = binary, false = decimal
+var currentRateUnit *string
+
+//go:generate go-enum -f=$GOFILE --marshal --names
+
+// ENUM(
+//
+//	Duration
+//	Number
+//	Bytes
+//	Rate
+//
+// )
+type ValueKind uint
+
+func parse(element string, previousKind *ValueKind) (value float64, kind ValueKind, err error) {
+	quantity, err := cli.ParseQuantity(element)
+	if err != nil {
+		return 0, ValueKindNumber, err
+	}
+
+	switch quantity.Kind {
+	case cli.QuantityKindBytes:
+		// Track detected base on first byte input
+		if detectedBase == nil {
+			detectedBase = &quantity.Binary
+		}
+
+		return quantity.Value, ValueKindBytes, nil
+
+	case cli.QuantityKindDuration:
+		return quantity.Value, ValueKindDuration, nil
+
+	case cli.QuantityKindRate:
+		if currentRateUnit == nil {
+			currentRateUnit = &quantity.RateUnit
+		} else if *currentRateUnit != quantity.RateUnit {
+			return 0, ValueKindRate, fmt.Errorf("all rates should have the same unit, %q and %q are not", *currentRateUnit+"/s", quantity.RateUnit+"/s")
+		}
+
+		if quantity.RateUnit == "B" && detectedBase == nil {
+			detectedBase = &quantity.Binary
+		}
+
+		return quantity.Value, ValueKindRate, nil
+	}
+
+	// If previous kind was bytes, treat plain numbers as raw bytes
+	if previousKind != nil && *previousKind == ValueKindBytes {
+		return quantity.Value, ValueKindBytes, nil
+	}
+
+	return quantity.Value, ValueKindNumber, nil
+}
+
+func standardDeviation(mean float64, distribution []float64) float64 {
+	sumSquaredDiffToMean := 0.0
+	for _, value := range distribution {
+		sumSquaredDiffToMean += math.Pow(mean-value, 2)
+	}
+
+	if sumSquaredDiffToMean == 0 {
+		return 0.0
+	}
+
+	return math.Sqrt(sumSquaredDiffToMean / float64(len(distribution)-1))
+}
+
+func percentile(distribution []float64, p float64) float64 {
+	if len(distribution) == 0 {
+		return 0
+	}
+	if len(distribution) == 1 {
+		return distribution[0]
+	}
+
+	rank := (p / 100.0) * float64(len(distribution)-1)
+	lowerIndex := int(math.Floor(rank))
+	upperIndex := int(math.Ceil(rank))
+
+	if lowerIndex == upperIndex {
+		return distribution[lowerIndex]
+	}
+
+	// Linear interpolation between the two nearest values
+	weight := rank - float64(lowerIndex)
+	return distribution[lowerIndex]*(1-weight) + distribution[upperIndex]*weight
+}
+
+type count uint64
+
+func (c count) String() string {
+	value := strconv.FormatUint(uint64(c), 10)
+	if *unit == "" {
+		return value
+	}
+
+	return value + *unit
+}
+
+type float float64
+
+func (f float) String() string {
+	value := strconv.FormatFloat(float64(f), 'f', 3, 64)
+	if *unit == "" {
+		return value
+	}
+
+	return value + *unit
+}
+
+type integer float64
+
+func (i integer) String() string {
+	value := strconv.FormatInt(int64(i), 10)
+	if *unit == "" {
+		return value
+	}
+
+	return value + *unit
+}
+
+func formatIntOrFloat(value float64) string {
+	if value == float64(int64(value)) {
+		return integer(value).String()
+	}
+	return float(value).String()
+}
+
+type duration float64
+
+func (f duration) String() string {
+	return time.Duration(f).String()
+}
+
+type bytes float64
+
+func (b bytes) String() string {
+	return formatBytes(float64(b), *unit)
+}
+
+func formatBytes(value float64, unitFlag string) string {
+	// Case 1: --unit flag is a byte unit
+	if isByteUnit(unitFlag) {
+		return formatBytesAsUnit(value, unitFlag)
+	}
+
+	// Case 2: --unit flag is NOT a byte unit
+	if unitFlag != "" {
+		return fmt.Sprintf("%.0f %s", value, unitFlag)
+	}
+
+	// Case 3: --unit flag is empty - humanize
+	return humanizeBytesWithBase(value)
+}
+
+func isByteUnit(unit string) bool {
+	_, found := cli.LookupByteUnit(unit)
+	return found
+}
+
+func formatBytesAsUnit(bytes float64, targetUnit string) string {
+	unit, found := cli.LookupByteUnit(targetUnit)
+	if !found {
+		return fmt.Sprintf("%.2f %s", bytes, targetUnit)
+	}
+
+	converted := bytes / unit.Multiplier
+	return fmt.Sprintf("%.2f %s", converted, unit.Name)
+}
+
+func humanizeBytesWithBase(bytes float64) string {
+	// Use detected base, default to binary
+	useBinary := true
+	if detectedBase != nil {
+		useBinary = *detectedBase
+	}
+
+	return cli.FormatBytes(bytes, useBinary)
+}
@@ -4,7 +4,7 @@
 // Build Date:
 // Built By:
 
-package main
+package stats
 
 import (
 	"fmt"
@@ -18,16 +18,19 @@ const (
 	ValueKindNumber
 	// ValueKindBytes is a ValueKind of type Bytes.
 	ValueKindBytes
+	// ValueKindRate is a ValueKind of type Rate.
+	ValueKindRate
 )
 
 var ErrInvalidValueKind = fmt.Errorf("not a valid ValueKind, try [%s]", strings.Join(_ValueKindNames, ", "))
 
-const _ValueKindName = "DurationNumberBytes"
+const _ValueKindName = "DurationNumberBytesRate"
 
 var _ValueKindNames = []string{
 	_ValueKindName[0:8],
 	_ValueKindName[8:14],
 	_ValueKindName[14:19],
+	_ValueKindName[19:23],
 }
 
 // ValueKindNames returns a list of possible string values of ValueKind.
@@ -41,6 +44,7 @@ var _ValueKindMap = map[ValueKind]string{
 	ValueKindDuration: _ValueKindName[0:8],
 	ValueKindNumber:   _ValueKindName[8:14],
 	ValueKindBytes:    _ValueKindName[14:19],
+	ValueKindRate:     _ValueKindName[19:23],
 }
 
 // String implements the Stringer interface.
@@ -62,6 +66,7 @@ var _ValueKindValue = map[string]ValueKind{
 	_ValueKindName[0:8]:   ValueKindDuration,
 	_ValueKindName[8:14]:  ValueKindNumber,
 	_ValueKindName[14:19]: ValueKindBytes,
+	_ValueKindName[19:23]: ValueKindRate,
 }
 
 // ParseValueKind attempts to convert a string to a ValueKind.
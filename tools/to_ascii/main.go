@@ -0,0 +1,122 @@
+package toascii
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_ascii", flag.ExitOnError)
+
+// Tool is to_ascii runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_ascii", Short: "Converts input to ASCII string", Main: Main}
+
+var asBinaryFlag = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a binary representation")
+var utf8Flag = flags.Bool("utf8", false, "Decode valid UTF-8 sequences as characters, bytes not part of a valid sequence are rendered one by one as unprintable")
+var escapeFlag = flags.String("escape", "dot", "How unprintable characters are rendered, 'dot' (.), 'hex' (\\xNN), 'go' (Go quoted string) or 'caret' (^A and M-^A like 'cat -v'), new lines and tabs are kept as is except with 'go'")
+var stringsFlag = flags.Bool("strings", false, "Print only the runs of printable characters of at least -min characters, each prefixed by its byte offset in hexadecimal like 'strings -t x'")
+var minFlag = flags.Int("min", 4, "With -strings, the minimum number of characters of a run to print it")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	escape, err := parseEscapeMode(*escapeFlag)
+	cli.NoError(err, "invalid -escape value")
+
+	cli.Ensure(!*stringsFlag || !cli.IsFlagSetIn(flags, "escape"), "Flag -escape cannot be used with -strings")
+	cli.Ensure(*stringsFlag || !cli.IsFlagSetIn(flags, "min"), "Flag -min can only be used with -strings")
+	cli.Ensure(*minFlag > 0, "Flag -min must be at least 1")
+
+	if *asBinaryFlag {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		if *stringsFlag {
+			extractor := newStringsExtractor(func(line string) { fmt.Println(line) })
+
+			cli.ProcessInputBytes(flags.Args(), cli.ChunkAlignmentByte, func(bytes []byte) { extractor.write(bytes, false) })
+			extractor.write(nil, true)
+
+			return
+		}
+
+		renderer := &asciiRenderer{escape: escape, decodeUTF8: *utf8Flag}
+		if escape == escapeGo {
+			fmt.Print(`"`)
+		}
+
+		cli.ProcessInputBytes(flags.Args(), cli.ChunkAlignmentByte, func(bytes []byte) {
+			fmt.Print(bytesToText(renderer, bytes, false))
+		})
+		fmt.Print(bytesToText(renderer, nil, true))
+
+		if escape == escapeGo {
+			fmt.Print(`"`)
+		}
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		return toAscii(element, inputCodec, escape)
+	})
+}
+
+func toAscii(element string, inputCodec *cli.Codec, escape escapeMode) (string, string, error) {
+	if element == "" {
+		return "", "", nil
+	}
+
+	if inputCodec == nil {
+		if !cli.HexRegexp.MatchString(element) {
+			return element, "", nil
+		}
+
+		inputCodec = cli.MustLookupCodec("hex")
+	}
+
+	bytes, err := inputCodec.Decode(element)
+	if err != nil {
+		return "", "", err
+	}
+
+	if *stringsFlag {
+		var lines []string
+		extractor := newStringsExtractor(func(line string) { lines = append(lines, line) })
+		extractor.write(bytes, true)
+
+		return strings.Join(lines, "\n"), inputCodec.Name, nil
+	}
+
+	out := bytesToText(&asciiRenderer{escape: escape, decodeUTF8: *utf8Flag}, bytes, true)
+	if escape == escapeGo {
+		out = `"` + out + `"`
+	}
+
+	return out, inputCodec.Name, nil
+}
+
+// bytesToText renders `bytes` with `renderer` unless the default rendering is used in which
+// case it's [cli.BytesToAscii], kept as is since it also prints bytes above 0x7f as Latin-1
+func bytesToText(renderer *asciiRenderer, bytes []byte, final bool) string {
+	if renderer.escape == escapeDot && !renderer.decodeUTF8 {
+		return cli.BytesToAscii(bytes)
+	}
+
+	return renderer.render(bytes, final)
+}
+
+func newStringsExtractor(println func(line string)) *stringsExtractor {
+	return &stringsExtractor{minLength: *minFlag, decodeUTF8: *utf8Flag, emit: func(offset int64, value string) {
+		println(fmt.Sprintf("%7x %s", offset, value))
+	}}
+}
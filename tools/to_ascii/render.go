@@ -0,0 +1,128 @@
+package toascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// escapeMode is how the characters that cannot be printed as is are rendered
+type escapeMode string
+
+const (
+	escapeDot   escapeMode = "dot"
+	escapeHex   escapeMode = "hex"
+	escapeGo    escapeMode = "go"
+	escapeCaret escapeMode = "caret"
+)
+
+var escapeModes = []escapeMode{escapeDot, escapeHex, escapeGo, escapeCaret}
+
+func parseEscapeMode(in string) (escapeMode, error) {
+	for _, mode := range escapeModes {
+		if string(mode) == in {
+			return mode, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown escape mode %q, valid modes are dot, hex, go and caret", in)
+}
+
+// nextUnit returns the first character of `bytes` and its size, `valid` is false for a byte that
+// is not part of a valid UTF-8 sequence when `decodeUTF8` is set, or that is above 0x7f otherwise.
+// When `more` is true, `bytes` holds the start of a UTF-8 sequence that could be completed by the
+// bytes that follow.
+func nextUnit(bytes []byte, decodeUTF8 bool) (character rune, size int, valid bool, more bool) {
+	if !decodeUTF8 {
+		return rune(bytes[0]), 1, bytes[0] < utf8.RuneSelf, false
+	}
+
+	character, size = utf8.DecodeRune(bytes)
+	if character == utf8.RuneError && size == 1 {
+		return character, 1, false, !utf8.FullRune(bytes)
+	}
+
+	return character, size, true, false
+}
+
+// asciiRenderer renders bytes as text escaping what cannot be printed, it can be fed with
+// consecutive chunks of an input, a UTF-8 sequence split between two chunks is decoded once
+// the next chunk is received.
+type asciiRenderer struct {
+	escape     escapeMode
+	decodeUTF8 bool
+	pending    []byte
+}
+
+// render renders `chunk`, `final` must be set on the last chunk of the input
+func (r *asciiRenderer) render(chunk []byte, final bool) string {
+	bytes := chunk
+	if len(r.pending) > 0 {
+		bytes, r.pending = append(r.pending, chunk...), nil
+	}
+
+	builder := strings.Builder{}
+	for len(bytes) > 0 {
+		character, size, valid, more := nextUnit(bytes, r.decodeUTF8)
+		if more && !final {
+			r.pending = append([]byte{}, bytes...)
+			break
+		}
+
+		r.renderUnit(&builder, bytes[:size], character, valid)
+		bytes = bytes[size:]
+	}
+
+	return builder.String()
+}
+
+func (r *asciiRenderer) renderUnit(builder *strings.Builder, unit []byte, character rune, valid bool) {
+	switch {
+	case valid && r.escape == escapeGo && (character == '"' || character == '\\'):
+		builder.WriteByte('\\')
+		builder.WriteRune(character)
+
+	case valid && unicode.IsPrint(character):
+		builder.WriteRune(character)
+
+	case valid && r.escape != escapeGo && unicode.IsSpace(character) && character < utf8.RuneSelf:
+		builder.WriteRune(character)
+
+	case r.escape == escapeDot:
+		builder.WriteByte('.')
+
+	case r.escape == escapeGo && valid:
+		quoted := strconv.QuoteRune(character)
+		builder.WriteString(quoted[1 : len(quoted)-1])
+
+	case r.escape == escapeHex || r.escape == escapeGo:
+		for _, byteValue := range unit {
+			fmt.Fprintf(builder, `\x%02x`, byteValue)
+		}
+
+	case r.escape == escapeCaret:
+		for _, byteValue := range unit {
+			builder.WriteString(caretNotation(byteValue))
+		}
+	}
+}
+
+// caretNotation renders `byteValue` like `cat -v` does, control characters as `^@` to `^_`,
+// DEL as `^?` and bytes above 0x7f as `M-` followed by the notation of their lower 7 bits.
+func caretNotation(byteValue byte) string {
+	switch {
+	case byteValue >= 0x80:
+		return "M-" + caretNotation(byteValue&0x7f)
+
+	case byteValue == 0x7f:
+		return "^?"
+
+	case byteValue < 0x20:
+		return "^" + string(rune(byteValue+0x40))
+
+	default:
+		return string(rune(byteValue))
+	}
+}
@@ -0,0 +1,57 @@
+package toascii
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var renderInput = []byte("h\xc3\xa9llo \xe2\x82\xac\x00\x01\t\x7f\xff\"\\\n")
+
+func Test_asciiRenderer(t *testing.T) {
+	tests := []struct {
+		escape     escapeMode
+		decodeUTF8 bool
+		want       string
+	}{
+		{escapeDot, true, "héllo €..\t..\"\\\n"},
+		{escapeHex, false, "h\\xc3\\xa9llo \\xe2\\x82\\xac\\x00\\x01\t\\x7f\\xff\"\\\n"},
+		{escapeHex, true, "héllo €\\x00\\x01\t\\x7f\\xff\"\\\n"},
+		{escapeGo, false, `h\xc3\xa9llo \xe2\x82\xac\x00\x01\t\x7f\xff\"\\\n`},
+		{escapeGo, true, `héllo €\x00\x01\t\x7f\xff\"\\\n`},
+		{escapeCaret, false, "hM-CM-)llo M-bM-^BM-,^@^A\t^?M-^?\"\\\n"},
+		{escapeCaret, true, "héllo €^@^A\t^?M-^?\"\\\n"},
+	}
+
+	for _, tt := range tests {
+		name := string(tt.escape)
+		if tt.decodeUTF8 {
+			name += "_utf8"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			renderer := &asciiRenderer{escape: tt.escape, decodeUTF8: tt.decodeUTF8}
+			assert.Equal(t, tt.want, renderer.render(renderInput, true))
+
+			// Split in the middle of the € sequence, it must be rendered once the rest is received
+			renderer = &asciiRenderer{escape: tt.escape, decodeUTF8: tt.decodeUTF8}
+			assert.Equal(t, tt.want, renderer.render(renderInput[:8], false)+renderer.render(renderInput[8:], false)+renderer.render(nil, true))
+		})
+	}
+}
+
+func Test_asciiRenderer_TruncatedSequence(t *testing.T) {
+	renderer := &asciiRenderer{escape: escapeHex, decodeUTF8: true}
+
+	assert.Equal(t, "ok", renderer.render([]byte("ok\xe2\x82"), false))
+	assert.Equal(t, `\xe2\x82`, renderer.render(nil, true))
+}
+
+func Test_caretNotation(t *testing.T) {
+	assert.Equal(t, "^@", caretNotation(0x00))
+	assert.Equal(t, "^[", caretNotation(0x1b))
+	assert.Equal(t, "a", caretNotation('a'))
+	assert.Equal(t, "^?", caretNotation(0x7f))
+	assert.Equal(t, "M-^@", caretNotation(0x80))
+	assert.Equal(t, "M-a", caretNotation(0xe1))
+}
@@ -0,0 +1,61 @@
+package toascii
+
+import "unicode"
+
+// stringsExtractor finds the runs of at least `minLength` printable characters of an input like
+// `strings` does, a tab is considered printable. It can be fed with consecutive chunks of the
+// input, runs spanning several chunks are found whole.
+type stringsExtractor struct {
+	minLength  int
+	decodeUTF8 bool
+	emit       func(offset int64, value string)
+
+	offset    int64
+	pending   []byte
+	run       []byte
+	runStart  int64
+	runLength int
+}
+
+// write processes `chunk`, `final` must be set on the last chunk of the input
+func (e *stringsExtractor) write(chunk []byte, final bool) {
+	bytes := chunk
+	if len(e.pending) > 0 {
+		bytes, e.pending = append(e.pending, chunk...), nil
+	}
+
+	for len(bytes) > 0 {
+		character, size, valid, more := nextUnit(bytes, e.decodeUTF8)
+		if more && !final {
+			e.pending = append([]byte{}, bytes...)
+			break
+		}
+
+		if valid && (character == '\t' || unicode.IsPrint(character)) {
+			if e.runLength == 0 {
+				e.runStart = e.offset
+			}
+
+			e.run = append(e.run, bytes[:size]...)
+			e.runLength++
+		} else {
+			e.endRun()
+		}
+
+		e.offset += int64(size)
+		bytes = bytes[size:]
+	}
+
+	if final {
+		e.endRun()
+	}
+}
+
+func (e *stringsExtractor) endRun() {
+	if e.runLength >= e.minLength && e.runLength > 0 {
+		e.emit(e.runStart, string(e.run))
+	}
+
+	e.run = e.run[:0]
+	e.runLength = 0
+}
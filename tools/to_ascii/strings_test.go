@@ -0,0 +1,41 @@
+package toascii
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stringsExtractor(t *testing.T) {
+	input := []byte("abc\x00hello\tworld\x01\x02\xc3\xa9t\xc3\xa9 long\x00ab")
+
+	tests := []struct {
+		name       string
+		minLength  int
+		decodeUTF8 bool
+		want       []string
+	}{
+		{"bytes", 4, false, []string{"4 hello\tworld", "16  long"}},
+		{"utf8", 4, true, []string{"4 hello\tworld", "11 été long"}},
+		{"min length", 2, false, []string{"0 abc", "4 hello\tworld", "16  long", "1c ab"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, split := range []int{len(input), 18, 1} {
+				var got []string
+				extractor := &stringsExtractor{minLength: tt.minLength, decodeUTF8: tt.decodeUTF8, emit: func(offset int64, value string) {
+					got = append(got, fmt.Sprintf("%x %s", offset, value))
+				}}
+
+				for start := 0; start < len(input); start += split {
+					extractor.write(input[start:min(start+split, len(input))], false)
+				}
+				extractor.write(nil, true)
+
+				assert.Equal(t, tt.want, got, "split every %d bytes", split)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package tobase58
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_base58", flag.ExitOnError)
+
+// Tool is to_base58 runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_base58", Short: "Converts input to Base58 encoded string", Main: Main}
+
+var fromStdIn = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a bytes stream")
+
+// The output flags mirror the -b58c and -b58c-version input flags of cli.RegisterCodecFlags
+var checkFlag = flags.Bool("b58c-out", false, "Encode using Base58Check, appending a 4 bytes double SHA-256 checksum, the output counterpart of -b58c")
+var versionFlag = flags.String("b58c-out-version", "", "With -b58c-out, the version prefix in hex prepended to the bytes (e.g. 00 for Bitcoin, 41 for Tron addresses), the output counterpart of -b58c-version")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	cli.Ensure(*versionFlag == "" || *checkFlag, "Flag -b58c-out-version can only be used with -b58c-out")
+
+	base58Codec := cli.MustLookupCodec("base58")
+	if *checkFlag {
+		base58Codec, err = cli.LookupCodec("base58check:" + *versionFlag)
+		cli.NoError(err, "invalid version")
+	}
+
+	if *fromStdIn {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		// Base58 treats the whole input as a single big number, it cannot be streamed
+		cli.ProcessInputBytes(flags.Args(), base58Codec.Alignment(), func(bytes []byte) {
+			out, err := base58Codec.Encode(bytes)
+			cli.NoError(err, "unable to encode input")
+
+			fmt.Print(out)
+		})
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		if element == "" {
+			return "", "", nil
+		}
+
+		return cli.Convert(element, inputCodec, base58Codec)
+	})
+}
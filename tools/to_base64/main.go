@@ -0,0 +1,54 @@
+package tobase64
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_base64", flag.ExitOnError)
+
+// Tool is to_base64 runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_base64", Short: "Converts input to Base64 encoded string", Main: Main}
+
+var fromStdIn = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a bytes stream")
+var toUrlFlag = flags.Bool("url", false, "If true, used base64 URL encoder (not padded) instead of the standard non-URL safe one")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	outputCodec := cli.MustLookupCodec("base64")
+	if *toUrlFlag {
+		outputCodec = cli.MustLookupCodec("base64urlraw")
+	}
+
+	if *fromStdIn {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		cli.ProcessInputBytes(flags.Args(), outputCodec.Alignment(), func(bytes []byte) {
+			out, err := outputCodec.Encode(bytes)
+			cli.NoError(err, "unable to encode input")
+
+			fmt.Print(out)
+		})
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		if element == "" {
+			return "", "", nil
+		}
+
+		return cli.Convert(element, inputCodec, outputCodec)
+	})
+}
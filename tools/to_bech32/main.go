@@ -0,0 +1,75 @@
+package tobech32
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_bech32", flag.ExitOnError)
+
+// Tool is to_bech32 runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_bech32", Short: "Converts input to Bech32 encoded string", Main: Main}
+
+var fromStdIn = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a bytes stream")
+var _ = flags.Bool("url", false, "Deprecated, has no effect, bech32 has a single alphabet")
+var hrpFlag = flags.String("hrp", "sei", "The human-readable part that should be appended at the front of the address")
+var bech32mFlag = flags.Bool("m", false, "Encode using the Bech32m checksum (BIP-350) instead of the original Bech32 one")
+var witnessVersionFlag = flags.Int("witness-version", -1, "Encode the input as the witness program of a SegWit address with this witness version (0 to 16), the checksum variant is picked from the version (Bech32 for 0, Bech32m otherwise)")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	cli.Ensure(*witnessVersionFlag < 0 || !*bech32mFlag, "Flag -m cannot be used with -witness-version which already picks the checksum variant")
+	cli.Ensure(*witnessVersionFlag <= 16, "Flag -witness-version must be between 0 and 16, got %d", *witnessVersionFlag)
+
+	outputCodec, err := cli.LookupCodec("bech32:" + *hrpFlag)
+	if *bech32mFlag {
+		outputCodec, err = cli.LookupCodec("bech32m:" + *hrpFlag)
+	}
+	cli.NoError(err, "invalid human-readable part %q", *hrpFlag)
+
+	encode := outputCodec.Encode
+	if *witnessVersionFlag >= 0 {
+		encode = func(program []byte) (string, error) {
+			return cli.EncodeSegWit(*hrpFlag, byte(*witnessVersionFlag), program)
+		}
+	}
+
+	if *fromStdIn {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		// Bech32 checksum covers the whole payload, it cannot be streamed
+		cli.ProcessInputBytes(flags.Args(), outputCodec.Alignment(), func(bytes []byte) {
+			out, err := encode(bytes)
+			cli.NoError(err, "unable to encode standard input")
+
+			fmt.Print(out)
+		})
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		if element == "" {
+			return "", "", nil
+		}
+
+		bytes, kind, err := cli.DecodeElement(element, inputCodec)
+		if err != nil {
+			return "", kind, err
+		}
+
+		out, err := encode(bytes)
+		return out, kind, err
+	})
+}
@@ -0,0 +1,172 @@
+package todate
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_date", flag.ExitOnError)
+
+// Tool is to_date runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_date", Short: "Converts input to ISO-8601 string format", Main: Main}
+
+var asUnixSecondsFlag = flags.Bool("s", false, "Avoid heuristics based to determine decimal range value and assume it's UNIX seconds since epoch, mutually exclusive with -ms, -us and -ns")
+var asUnixMillisFlag = flags.Bool("ms", false, "Avoid heuristics based to determine decimal range value and assume it's UNIX milliseconds since epoch, mutually exclusive with -s, -us and -ns")
+var asUnixMicrosFlag = flags.Bool("us", false, "Avoid heuristics based to determine decimal range value and assume it's UNIX microseconds since epoch, mutually exclusive with -s, -ms and -ns")
+var asUnixNanosFlag = flags.Bool("ns", false, "Avoid heuristics based to determine decimal range value and assume it's UNIX nanoseconds since epoch, mutually exclusive with -s, -ms and -us")
+var explainFlag = flags.Bool("explain", false, "Print how each date was parsed below it (in the 'explain' field with -output json|jsonl): the layout that matched, where the timezone comes from, the epoch unit decision and the date components filled from current date")
+var timezoneFlag = flags.String("timezone", "local", "When the provided date is not timezone aware, use this timezone to interpret it. Valid values are 'local', 'utc', 'z' or a valid timezone name.")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterTimezoneRegionsFlags(flags)
+	cli.RegisterDateExtractionFlags(flags)
+	cli.RegisterInlineFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	if units := epochUnitFlags(); len(units) > 1 {
+		fmt.Fprintf(flags.Output(), "Flags %s are mutually exclusive, use only one of them\n", strings.Join(units, ", "))
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	timezoneIfUnset := time.Local
+	if *timezoneFlag != "" {
+		var err error
+		timezoneIfUnset, err = cli.ParseTimezone(*timezoneFlag)
+		cli.NoError(err, "invalid timezone provided")
+	}
+
+	converter := func(element string) cli.Result {
+		out, kind, explanation, err := toDate(element, timezoneIfUnset)
+		if !*explainFlag {
+			explanation = nil
+		}
+
+		return cli.Result{Input: element, Output: out, Kind: kind, Error: err, Explain: explanation}
+	}
+
+	if cli.InlineEnabled() {
+		cli.Ensure(!cli.DateExtractionEnabled() && !*explainFlag, "Flag -inline cannot be used at the same time as -extract nor -explain")
+
+		converter = cli.InlineConverter(cli.DateTokenMatcher(timezoneIfUnset), func(element string) (string, string, error) {
+			return toInlineDate(element, timezoneIfUnset)
+		}).Results()
+	}
+
+	cli.ConvertArgumentsToResults(cli.NewArgumentScanner(flags.Args()), converter, func() cli.Result {
+		return cli.Result{Output: formatDate(time.Now()), Kind: "now"}
+	})
+}
+
+// toDate converts element to its dates, `explanation` describes how the date was obtained
+// and is reported when `-explain` is used
+func toDate(element string, timezoneIfUnset *time.Location) (out string, kind string, explanation []string, err error) {
+	if cli.DateExtractionEnabled() {
+		match, err := cli.ExtractDateLikeInputFromFlags(element, timezoneIfUnset)
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		explanation = append([]string{fmt.Sprintf("extracted: %q at [%d:%d]", element[match.Start:match.End], match.Start, match.End)}, match.Trace.Lines()...)
+		return formatDate(match.Time), strings.ToLower(match.Trace.ParsedFrom.String()), explanation, nil
+	}
+
+	location, detail, err := cli.ResolveTimeZoneAbbreviation(element)
+	if err == nil {
+		// There is just a location, gives the current time in that location
+		now := time.Now().In(location)
+
+		return formatDate(now), "timezone", []string{fmt.Sprintf("zone: %q resolved to %s, %s", element, now.Format("-07:00"), detail)}, nil
+	}
+
+	if !errors.Is(err, cli.ErrUnknownTimeZoneAbbreviation) {
+		return "", "", nil, err
+	}
+
+	parsed, trace, err := parseDate(element, timezoneIfUnset)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return formatDate(parsed), strings.ToLower(trace.ParsedFrom.String()), trace.Lines(), nil
+}
+
+// toInlineDate converts a date token found inside a line, see `-inline`, to a single RFC3339 local date
+func toInlineDate(element string, timezoneIfUnset *time.Location) (out string, kind string, err error) {
+	parsed, trace, err := parseDate(element, timezoneIfUnset)
+	if err != nil {
+		return "", "", err
+	}
+
+	return formatTime(parsed.Local()), strings.ToLower(trace.ParsedFrom.String()), nil
+}
+
+// epochUnitFlags returns the names of the epoch unit flags (-s, -ms, -us and -ns) that are set
+func epochUnitFlags() (names []string) {
+	for _, unit := range []struct {
+		name  string
+		value *bool
+	}{{"-s", asUnixSecondsFlag}, {"-ms", asUnixMillisFlag}, {"-us", asUnixMicrosFlag}, {"-ns", asUnixNanosFlag}} {
+		if *unit.value {
+			names = append(names, unit.name)
+		}
+	}
+
+	return names
+}
+
+func parseDate(element string, timezoneIfUnset *time.Location) (time.Time, *cli.DateParseTrace, error) {
+	hint := cli.DateLikeHintNone
+	switch {
+	case *asUnixMillisFlag:
+		hint = cli.DateLikeHintUnixMilliseconds
+	case *asUnixMicrosFlag:
+		hint = cli.DateLikeHintUnixMicroseconds
+	case *asUnixNanosFlag:
+		hint = cli.DateLikeHintUnixNanoseconds
+	case *asUnixSecondsFlag:
+		hint = cli.DateLikeHintUnixSeconds
+	}
+
+	parsed, trace, ok := cli.ExplainDateLikeInput(element, hint, timezoneIfUnset)
+	if !ok {
+		if trace.Err != nil {
+			return parsed, trace, fmt.Errorf("unable to interpret %q as a date: %w", element, trace.Err)
+		}
+
+		return parsed, trace, fmt.Errorf("unable to interpret %q as a date", element)
+	}
+
+	return parsed, trace, nil
+}
+
+func formatDate(in time.Time) string {
+	local := in.Local()
+	utc := in.UTC()
+
+	_, inOffset := in.Zone()
+	_, localOffset := local.Zone()
+	_, utcOffset := utc.Zone()
+
+	// Prints only current timezone + UTC version
+	if inOffset == localOffset || inOffset == utcOffset {
+		return fmt.Sprintf("%s (%s)", formatTime(local), formatTime(utc))
+	}
+
+	return fmt.Sprintf("%s (%s, %s)", formatTime(local), formatTime(utc), formatTime(in))
+}
+
+func formatTime(in time.Time) string {
+	if in.Nanosecond() > 0 {
+		return in.Format(time.RFC3339Nano)
+	}
+
+	return in.Format(time.RFC3339)
+}
@@ -0,0 +1,253 @@
+package todec
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_dec", flag.ExitOnError)
+
+// Tool is to_dec runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_dec", Short: "Converts input to integer (arbitrary precision)", Main: Main}
+
+var humanizeFlag = flags.Bool("h", false, "Humanize the output number")
+var reversedFlag = flags.Bool("r", false, "Decode assuming the input value is a reverted number")
+var signedFlag = flags.Bool("signed", false, "Decode bytes as a signed two's complement number, use -width to set the number size")
+var littleEndianFlag = flags.Bool("le", false, "Decode bytes as a little-endian number, also the default endianness of -struct fields")
+var offsetFlag = flags.Int("offset", 0, "Decode from this byte offset of the input, the bytes before it are ignored")
+var structFlag = flags.String("struct", "", "Decode consecutive fields, e.g. 'u32le,u64be,i128', u (unsigned) or i (signed) followed by the size in bits and optionally le or be, printed separated by a space")
+
+var structFields []structField
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterInlineFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberDecodeFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	cli.Ensure(*offsetFlag >= 0, "Flag -offset must be positive")
+	cli.Ensure(!*reversedFlag || (!*signedFlag && !*littleEndianFlag && *structFlag == ""), "Flag -r cannot be used with -signed, -le nor -struct")
+
+	if *structFlag != "" {
+		cli.Ensure(!*signedFlag && cli.NumberWidth() == 0, "Flags -signed and -width cannot be used with -struct, each field defines its sign and size")
+
+		structFields, err = parseStructSpec(*structFlag, *littleEndianFlag)
+		cli.NoError(err, "invalid -struct value")
+	}
+
+	converter := func(element string) (string, string, error) {
+		if inputCodec != nil {
+			return codecToDec(element, inputCodec)
+		}
+
+		return toDec(element)
+	}
+
+	if cli.InlineEnabled() {
+		cli.EnsureNoCodecFlag(inputCodec, "-inline")
+		cli.Ensure(len(bytesFlags()) == 0, "Flags %s cannot be used with -inline, they only apply to bytes inputs", strings.Join(bytesFlags(), ", "))
+
+		converter = cli.InlineConverter(cli.CombineTokenMatchers(cli.HexTokenMatcher, cli.DecimalTokenMatcher), toInlineDec)
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), converter)
+}
+
+// toInlineDec converts a number token found inside a line, see `-inline`, contrary to whole
+// elements, decimal tokens are kept decimal (and humanized with -h) and only `0x` prefixed
+// tokens are decoded as hexadecimal.
+func toInlineDec(element string) (string, string, error) {
+	if cli.DecRegexp.MatchString(element) {
+		value, _ := new(big.Int).SetString(element, 10)
+		return formatNumber(value), "decimal", nil
+	}
+
+	return toDec(element)
+}
+
+var scientificNotationRegexp = regexp.MustCompile(`^([0-9]+)?\.[0-9]+(e|E)\+[0-9]+$`)
+
+// codecToDec converts the bytes represented by `element` in `inputCodec` as a big-endian unsigned integer
+func codecToDec(element string, inputCodec *cli.Codec) (string, string, error) {
+	if element == "" {
+		return "", "", nil
+	}
+
+	value, err := inputCodec.Decode(element)
+	if err != nil {
+		return "", "", err
+	}
+
+	out, err := bytesToDec(value)
+	if err != nil {
+		return "", "", err
+	}
+
+	return out, inputCodec.Name, nil
+}
+
+func toDec(element string) (string, string, error) {
+	if cli.HexRegexp.MatchString(element) {
+		value, err := cli.DecodeHex(element)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid number %q: %w", element, err)
+		}
+
+		out, err := bytesToDec(value)
+		if err != nil {
+			return "", "", err
+		}
+
+		return out, "hex", nil
+	}
+
+	if cli.DecRegexp.MatchString(element) || scientificNotationRegexp.MatchString(element) {
+		if used := bytesFlags(); len(used) > 0 {
+			return "", "", fmt.Errorf("flags %s only apply to bytes inputs (hexadecimal or decoded by -from), not to the decimal number %q", strings.Join(used, ", "), element)
+		}
+	}
+
+	// So we handle humanize for decimal number correctly
+	if cli.DecRegexp.MatchString(element) {
+		bigValue, _ := new(big.Int).SetString(element, 10)
+		return formatNumber(bigValue), "decimal", nil
+	}
+
+	if scientificNotationRegexp.MatchString(element) {
+		if bigValue, err := cli.ParseNumber(element); err == nil {
+			return formatNumber(bigValue), "scientific", nil
+		}
+
+		// Not an exact integer, truncate it
+		flt, _, err := big.ParseFloat(element, 10, 0, big.ToNearestEven)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid scientific notation %q: %w", element, err)
+		}
+
+		bigValue, _ := flt.Int(new(big.Int))
+		return formatNumber(bigValue), "scientific", nil
+	}
+
+	return element, "", nil
+}
+
+// bytesFlags returns the flags used among the ones decoding the number from bytes, which have
+// no meaning for decimal inputs
+func bytesFlags() (used []string) {
+	for _, name := range []string{"r", "signed", "le", "offset", "width", "struct"} {
+		if cli.IsFlagSetIn(flags, name) {
+			used = append(used, "-"+name)
+		}
+	}
+
+	return used
+}
+
+// bytesToDec decodes the number found at -offset of `value`, on -width bytes or up to the end,
+// according to -signed, -le and -r, or the -struct fields
+func bytesToDec(value []byte) (string, error) {
+	if *offsetFlag > len(value) {
+		return "", fmt.Errorf("offset %d is past the end of the %d bytes input", *offsetFlag, len(value))
+	}
+	value = value[*offsetFlag:]
+
+	if structFields != nil {
+		fieldValues, err := decodeStruct(value, structFields)
+		if err != nil {
+			return "", err
+		}
+
+		out := make([]string, len(fieldValues))
+		for i, fieldValue := range fieldValues {
+			out[i] = formatNumber(fieldValue)
+		}
+
+		return strings.Join(out, " "), nil
+	}
+
+	if width := cli.NumberWidth(); width > 0 {
+		if len(value) < width {
+			return "", fmt.Errorf("input has %d bytes from offset %d but -width requires %d bytes", len(value), *offsetFlag, width)
+		}
+
+		value = value[:width]
+	}
+
+	if !*reversedFlag {
+		return formatNumber(cli.BytesToNumber(value, *signedFlag, *littleEndianFlag)), nil
+	}
+
+	bigValue := new(big.Int).SetBytes(value)
+
+	if *reversedFlag && bigValue.BitLen() > 0 {
+		max := new(big.Int).Lsh(big.NewInt(1), uint(bigValue.BitLen()-1))
+		for i := 0; i < bigValue.BitLen(); i++ {
+			max.SetBit(max, i, 1)
+		}
+
+		bigValue = new(big.Int).Sub(max, bigValue)
+	}
+
+	return formatNumber(bigValue), nil
+}
+
+func formatNumber(number *big.Int) string {
+	if *humanizeFlag {
+		return humanize(number)
+	}
+
+	return number.String()
+}
+
+var b1000 = big.NewInt(1000)
+
+// humanize copied from https://github.com/dustin/go-humanize/blob/master/comma.go#L89 (MIT license)
+func humanize(b *big.Int) string {
+	sign := ""
+	if b.Sign() < 0 {
+		sign = "-"
+		b.Abs(b)
+	}
+
+	c := (&big.Int{}).Set(b)
+	_, m := orderOfMagnitude(c, b1000)
+	parts := make([]string, m+1)
+	j := len(parts) - 1
+
+	mod := &big.Int{}
+	for b.Cmp(b1000) >= 0 {
+		b.DivMod(b, b1000, mod)
+		parts[j] = strconv.FormatInt(mod.Int64(), 10)
+		switch len(parts[j]) {
+		case 2:
+			parts[j] = "0" + parts[j]
+		case 1:
+			parts[j] = "00" + parts[j]
+		}
+		j--
+	}
+
+	parts[j] = strconv.Itoa(int(b.Int64()))
+	return sign + strings.Join(parts[j:], " ")
+}
+
+func orderOfMagnitude(n, b *big.Int) (float64, int) {
+	mag := 0
+	m := &big.Int{}
+	for n.Cmp(b) >= 0 {
+		n.DivMod(n, b, m)
+		mag++
+	}
+	return float64(n.Int64()) + (float64(m.Int64()) / float64(b.Int64())), mag
+}
@@ -0,0 +1,68 @@
+package todec
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var structFieldRegexp = regexp.MustCompile(`^([ui])([0-9]+)(le|be)?$`)
+
+// structField is an integer field of a `-struct` spec like `u32le`, `i128` or `u64be`
+type structField struct {
+	spec         string
+	signed       bool
+	size         int
+	littleEndian bool
+}
+
+// parseStructSpec parses the comma separated fields of `spec`, a field is `u` (unsigned) or `i`
+// (signed) followed by its size in bits and optionally `le` or `be`, `littleEndian` is used when
+// the endianness is not specified.
+func parseStructSpec(spec string, littleEndian bool) ([]structField, error) {
+	var fields []structField
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+
+		matches := structFieldRegexp.FindStringSubmatch(part)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid field %q, expecting u or i followed by the size in bits and optionally le or be (e.g. u32le, i128)", part)
+		}
+
+		bits, err := strconv.Atoi(matches[2])
+		if err != nil || bits == 0 || bits%8 != 0 {
+			return nil, fmt.Errorf("invalid field %q, the size in bits must be a multiple of 8", part)
+		}
+
+		field := structField{spec: part, signed: matches[1] == "i", size: bits / 8, littleEndian: littleEndian}
+		if matches[3] != "" {
+			field.littleEndian = matches[3] == "le"
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// decodeStruct decodes the consecutive `fields` from the start of `bytes`, the remaining bytes
+// are ignored
+func decodeStruct(bytes []byte, fields []structField) ([]*big.Int, error) {
+	values := make([]*big.Int, len(fields))
+
+	offset := 0
+	for i, field := range fields {
+		if len(bytes)-offset < field.size {
+			return nil, fmt.Errorf("field #%d %s at offset %d requires %d bytes but only %d are left", i+1, field.spec, offset, field.size, len(bytes)-offset)
+		}
+
+		values[i] = cli.BytesToNumber(bytes[offset:offset+field.size], field.signed, field.littleEndian)
+		offset += field.size
+	}
+
+	return values, nil
+}
@@ -0,0 +1,45 @@
+package todec
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseStructSpec(t *testing.T) {
+	fields, err := parseStructSpec("u32le, U64BE,i128,u8", true)
+	require.NoError(t, err)
+	assert.Equal(t, []structField{
+		{"u32le", false, 4, true},
+		{"u64be", false, 8, false},
+		{"i128", true, 16, true},
+		{"u8", false, 1, true},
+	}, fields)
+
+	_, err = parseStructSpec("u32,f64", false)
+	assert.ErrorContains(t, err, `invalid field "f64"`)
+
+	_, err = parseStructSpec("i12", false)
+	assert.EqualError(t, err, `invalid field "i12", the size in bits must be a multiple of 8`)
+
+	_, err = parseStructSpec("u32,", false)
+	assert.ErrorContains(t, err, `invalid field ""`)
+}
+
+func Test_decodeStruct(t *testing.T) {
+	fields, err := parseStructSpec("u32le,u64be,i8,i16le", false)
+	require.NoError(t, err)
+
+	bytes, err := hex.DecodeString("ffffffff" + "0000000000000001" + "ff" + "d6ff" + "beef")
+	require.NoError(t, err)
+
+	values, err := decodeStruct(bytes, fields)
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(4294967295), big.NewInt(1), big.NewInt(-1), big.NewInt(-42)}, values)
+
+	_, err = decodeStruct(bytes[:10], fields)
+	assert.EqualError(t, err, "field #2 u64be at offset 4 requires 8 bytes but only 6 are left")
+}
@@ -0,0 +1,124 @@
+package toduration
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_duration", flag.ExitOnError)
+
+// Tool is to_duration runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_duration", Short: "Converts input to duration", Main: Main}
+
+var asNanoseconds = flags.Bool("ns", false, "Decode the value as having nanosecond unit")
+var asMicroseconds = flags.Bool("us", false, "Decode the value as having microsecond unit")
+var asMillisecondsFlag = flags.Bool("ms", false, "Decode the value as having millisecond unit")
+var asSecondsFlag = flags.Bool("s", false, "Decode the value as having second unit")
+var asMinutesFlag = flags.Bool("m", false, "Decode the value as having minute unit")
+var asHoursFlag = flags.Bool("h", false, "Decode the value as having hour unit")
+var asDaysFlag = flags.Bool("d", false, "Decode the value as having day unit (24h approximation)")
+var asWeeksFlag = flags.Bool("w", false, "Decode the value as having week unit (7d approximation)")
+
+var inferedUnit time.Duration
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterInlineFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	var unit = inferedUnit
+	switch {
+	case *asNanoseconds:
+		unit = time.Nanosecond
+	case *asMicroseconds:
+		unit = time.Microsecond
+	case *asMillisecondsFlag:
+		unit = time.Millisecond
+	case *asSecondsFlag:
+		unit = time.Second
+	case *asMinutesFlag:
+		unit = time.Minute
+	case *asHoursFlag:
+		unit = time.Hour
+	case *asDaysFlag:
+		unit = time.Hour * 24
+	case *asWeeksFlag:
+		unit = time.Hour * 24 * 7
+	}
+
+	converter := func(element string) (string, string, error) {
+		return toDuration(element, unit)
+	}
+
+	if cli.InlineEnabled() {
+		matcher := cli.DurationTokenMatcher
+		if unit != inferedUnit {
+			matcher = cli.CombineTokenMatchers(cli.DurationTokenMatcher, cli.DecimalTokenMatcher)
+		}
+
+		converter = cli.InlineConverter(matcher, converter)
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), converter)
+}
+
+func toDuration(element string, unit time.Duration) (string, string, error) {
+	if element == "" {
+		return "", "", nil
+	}
+
+	if cli.DecRegexp.MatchString(element) {
+		if unit == inferedUnit {
+			return "", "", fmt.Errorf("no time suffix detected, one of the unit flag -ns (Nanosecond), -us (Microseond), -ms (Millisecond), -s (Second), -m (Minute), -h (Hour), -d (Day) or -w (Week) must be provided in that case")
+		}
+
+		value, _ := strconv.ParseInt(element, 10, 64)
+
+		return cli.FormatDuration(time.Duration(value) * unit), "unit", nil
+	}
+
+	quantity, err := cli.ParseQuantity(element)
+	if err == nil && quantity.Kind == cli.QuantityKindDuration {
+		return durationToUnit(time.Duration(quantity.Value), unit), "duration", nil
+	}
+
+	return element, "", nil
+}
+
+func durationToUnit(d time.Duration, unit time.Duration) string {
+	if unit == inferedUnit {
+		return cli.FormatDuration(d)
+	}
+
+	switch unit {
+	case time.Nanosecond:
+		return strconv.FormatInt(d.Nanoseconds(), 10) + "ns"
+	case time.Microsecond:
+		usec := d / time.Microsecond
+		nusec := d % time.Microsecond
+
+		return strconv.FormatFloat(float64(usec)+float64(nusec)/1e3, 'f', 3, 64) + "µs"
+	case time.Millisecond:
+		msec := d / time.Millisecond
+		nmsec := d % time.Millisecond
+
+		return strconv.FormatFloat(float64(msec)+float64(nmsec)/1e6, 'f', 3, 64) + "ms"
+	case time.Second:
+		return strconv.FormatFloat(d.Seconds(), 'f', 3, 64) + "s"
+	case time.Minute:
+		return strconv.FormatFloat(d.Minutes(), 'f', 3, 64) + "m"
+	case time.Hour:
+		return strconv.FormatFloat(d.Hours(), 'f', 3, 64) + "h"
+	case time.Hour * 24:
+		return strconv.FormatFloat(d.Hours()/24.0, 'f', 3, 64) + "d"
+	case time.Hour * 24 * 7:
+		return strconv.FormatFloat(d.Hours()/(24.0*7), 'f', 3, 64) + "w"
+	default:
+		panic(fmt.Errorf("invalid unit %s, should have matched one of the pre-defined unit", unit))
+	}
+}
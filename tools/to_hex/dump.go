@@ -0,0 +1,156 @@
+package tohex
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+// hexDumper renders bytes like `xxd` does, each line holds the offset of its first byte, `cols`
+// bytes as hexadecimal in groups of `group` bytes then the same bytes as ASCII.
+type hexDumper struct {
+	writer io.Writer
+	offset int64
+	cols   int
+	group  int
+}
+
+func newHexDumper(writer io.Writer, offset int64, cols int, group int) *hexDumper {
+	if group <= 0 || group > cols {
+		group = cols
+	}
+
+	return &hexDumper{writer: writer, offset: offset, cols: cols, group: group}
+}
+
+// dump writes the lines of `chunk`, it must hold full lines except for the last chunk of the input
+func (d *hexDumper) dump(chunk []byte) {
+	for len(chunk) > 0 {
+		line := chunk[:min(d.cols, len(chunk))]
+		fmt.Fprintln(d.writer, d.formatLine(line))
+
+		d.offset += int64(len(line))
+		chunk = chunk[len(line):]
+	}
+}
+
+func (d *hexDumper) formatLine(line []byte) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "%08x: ", d.offset)
+
+	groupCount := (d.cols + d.group - 1) / d.group
+	hexWidth := d.cols*2 + groupCount - 1
+
+	hexStart := builder.Len()
+	for i, byteValue := range line {
+		if i > 0 && i%d.group == 0 {
+			builder.WriteByte(' ')
+		}
+
+		fmt.Fprintf(&builder, "%02x", byteValue)
+	}
+
+	builder.WriteString(strings.Repeat(" ", hexWidth-(builder.Len()-hexStart)))
+	builder.WriteString("  ")
+	builder.WriteString(cli.BytesToAsciiLine(line))
+
+	return builder.String()
+}
+
+// maxReverseGap is the largest gap between two offsets of a hex dump filled with zeros by
+// [reverseHexDump], a larger gap is most likely a corrupted offset
+const maxReverseGap = 64 * 1024 * 1024
+
+// zeroReader is an infinite stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// reverseHexDump writes to `writer` the bytes of the hex dump read from `reader`. Lines with an
+// offset are read like `xxd -r` does, the hexadecimal part ends at the first double space where
+// the ASCII gutter starts and a gap between offsets is filled with zeros, up to
+// [maxReverseGap]. Lines without an offset are plain hexadecimal.
+func reverseHexDump(reader io.Reader, writer io.Writer) error {
+	lines := bufio.NewReader(reader)
+
+	var position int64
+	started := false
+
+	for lineNumber := 1; ; lineNumber++ {
+		line, readErr := lines.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return fmt.Errorf("unable to read input: %w", readErr)
+		}
+
+		if line = strings.TrimRight(line, " \t\r\n"); line != "" {
+			offset, digits, hasOffset, err := parseDumpLine(line)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+
+			if hasOffset {
+				if !started {
+					position = offset
+				}
+
+				if offset < position {
+					return fmt.Errorf("line %d: offset %08x goes back before the current offset %08x", lineNumber, offset, position)
+				}
+
+				if gap := offset - position; gap > maxReverseGap {
+					return fmt.Errorf("line %d: offset %08x leaves a gap of %d bytes after the current offset %08x, gaps larger than %d bytes are rejected", lineNumber, offset, gap, position, maxReverseGap)
+				}
+
+				if _, err := io.CopyN(writer, zeroReader{}, offset-position); err != nil {
+					return err
+				}
+				position = offset
+			}
+			started = true
+
+			bytes, err := hex.DecodeString(digits)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid hexadecimal %q: %w", lineNumber, digits, err)
+			}
+
+			if _, err := writer.Write(bytes); err != nil {
+				return err
+			}
+			position += int64(len(bytes))
+		}
+
+		if readErr != nil {
+			return nil
+		}
+	}
+}
+
+// parseDumpLine returns the offset and the hexadecimal digits of a hex dump line, `hasOffset`
+// is false when the line is plain hexadecimal
+func parseDumpLine(line string) (offset int64, digits string, hasOffset bool, err error) {
+	prefix, content, found := strings.Cut(line, ":")
+	if !found {
+		return 0, strings.Join(strings.Fields(line), ""), false, nil
+	}
+
+	offset, err = strconv.ParseInt(strings.TrimSpace(prefix), 16, 64)
+	if err != nil {
+		return 0, "", false, fmt.Errorf("invalid offset %q", strings.TrimSpace(prefix))
+	}
+
+	content = strings.TrimPrefix(content, " ")
+	if end := strings.Index(content, "  "); end >= 0 {
+		content = content[:end]
+	}
+
+	return offset, strings.ReplaceAll(content, " ", ""), true, nil
+}
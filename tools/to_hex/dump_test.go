@@ -0,0 +1,93 @@
+package tohex
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dumpInput = []byte("hello world, this is a dump\n\x00\x01\xff")
+
+func Test_hexDumper(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int64
+		cols   int
+		group  int
+		want   string
+	}{
+		{"defaults", 0, 16, 2, `
+00000000: 6865 6c6c 6f20 776f 726c 642c 2074 6869  hello world, thi
+00000010: 7320 6973 2061 2064 756d 700a 0001 ff    s is a dump....
+`},
+		{"single bytes groups", 0x200, 8, 1, `
+00000200: 68 65 6c 6c 6f 20 77 6f  hello wo
+00000208: 72 6c 64 2c 20 74 68 69  rld, thi
+00000210: 73 20 69 73 20 61 20 64  s is a d
+00000218: 75 6d 70 0a 00 01 ff     ump....
+`},
+		{"no group", 0, 12, 0, `
+00000000: 68656c6c6f20776f726c642c  hello world,
+0000000c: 207468697320697320612064   this is a d
+00000018: 756d700a0001ff            ump....
+`},
+		{"uneven groups", 0, 5, 4, `
+00000000: 68656c6c 6f  hello
+00000005: 20776f72 6c   worl
+0000000a: 642c2074 68  d, th
+0000000f: 69732069 73  is is
+00000014: 20612064 75   a du
+00000019: 6d700a00 01  mp...
+0000001e: ff           .
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			dumper := newHexDumper(out, tt.offset, tt.cols, tt.group)
+			for chunk := range slices.Chunk(dumpInput, tt.cols*2) {
+				dumper.dump(chunk)
+			}
+
+			assert.Equal(t, strings.TrimPrefix(tt.want, "\n"), out.String())
+		})
+	}
+}
+
+func Test_reverseHexDump(t *testing.T) {
+	tests := []struct {
+		name    string
+		dump    string
+		want    []byte
+		wantErr string
+	}{
+		{"dump", "00000000: 6865 6c6c 6f20 776f 726c 642c 2074 6869  hello world, thi\n00000010: 7320 6973 2061 2064 756d 700a 0001 ff    s is a dump....\n", dumpInput, ""},
+		{"xxd gutter starting with spaces", "00000000: 2020 6869  \n00000004: 0a                 .", []byte("  hi\n"), ""},
+		{"relative to the first offset", "00000200: 6869  hi\n00000204: 21  !\n", []byte("hi\x00\x00!"), ""},
+		{"plain hexadecimal", "6865 6c6c\r\n6f\n\n", []byte("hello"), ""},
+
+		{"huge gap", "00000000: 6869  hi\nffffffff: 21  !\n", nil, "line 2: offset ffffffff leaves a gap of 4294967293 bytes after the current offset 00000002, gaps larger than 67108864 bytes are rejected"},
+		{"offset going back", "00000010: 6869  hi\n00000000: 21  !\n", nil, "line 2: offset 00000000 goes back before the current offset 00000012"},
+		{"invalid offset", "zz: 6869  hi\n", nil, `line 1: invalid offset "zz"`},
+		{"odd digits", "686\n", nil, `line 1: invalid hexadecimal "686": encoding/hex: odd length hex string`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := reverseHexDump(strings.NewReader(tt.dump), out)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Bytes())
+		})
+	}
+}
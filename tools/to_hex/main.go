@@ -0,0 +1,125 @@
+package tohex
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_hex", flag.ExitOnError)
+
+// Tool is to_hex runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_hex", Short: "Converts input to hexadecimal encoded string", Main: Main}
+
+var fromStdIn = flags.Bool("in", false, "Decode the standard input (or the files received as arguments) as a bytes stream")
+
+var reversedFourFlag = flags.Bool("r4", false, "Encode back hexadecimal using reverted 4 bytes number, works only when using '-i' flag")
+var reversedEightFlag = flags.Bool("r", false, "Encode back hexadecimal using reverted 8 bytes number, works only when using '-i' flag")
+
+var dumpFlag = flags.Bool("dump", false, "With -in, render the bytes like 'xxd' does, the offset, the bytes as hexadecimal then as ASCII on each line")
+var colsFlag = flags.Int("cols", 16, "With -dump, the number of bytes per line")
+var groupFlag = flags.Int("group", 2, "With -dump, the number of bytes per group of hexadecimal digits, 0 means a single group per line")
+var skipFlag = flags.String("skip", "", "With -in, start at this byte offset of the input (e.g. 512 or 0x200)")
+var lengthFlag = flags.String("length", "", "With -in, stop after this many bytes of the input, all the remaining bytes by default")
+var reverseFlag = flags.Bool("reverse", false, "Turn a hex dump, as produced by -dump or 'xxd', or plain hexadecimal lines read from standard input (or the files received as arguments) back into raw bytes written to standard output")
+
+var hexCodec = cli.MustLookupCodec("hex")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterCodecFlags(flags)
+	cli.RegisterNumberFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	inputCodec, err := cli.CodecFromFlags()
+	cli.NoError(err, "invalid input codec")
+
+	if *reversedFourFlag || *reversedEightFlag {
+		cli.Ensure(inputCodec != nil && inputCodec.Name == "integer", "Flag -r or -r4 can only be used when input is an integer so -i must be provided")
+		cli.Ensure(cli.NumberWidth() == 0, "Flag -width cannot be used with -r or -r4 which already fix the width")
+	}
+
+	cli.Ensure(*fromStdIn || (*skipFlag == "" && *lengthFlag == "" && !*dumpFlag), "Flags -skip, -length and -dump can only be used with -in")
+	cli.Ensure(*dumpFlag || (!cli.IsFlagSetIn(flags, "cols") && !cli.IsFlagSetIn(flags, "group")), "Flags -cols and -group can only be used with -dump")
+	cli.Ensure(*colsFlag > 0 && *colsFlag <= 256, "Flag -cols must be between 1 and 256")
+	cli.Ensure(*groupFlag >= 0, "Flag -group must be positive")
+
+	if *reverseFlag {
+		cli.Ensure(!*fromStdIn, "Flag -reverse cannot be used with -in")
+		cli.EnsureNoCodecFlag(inputCodec, "-reverse")
+
+		reader, err := cli.OpenByteInput(flags.Args())
+		cli.NoError(err, "unable to open input")
+		defer reader.Close()
+
+		writer := bufio.NewWriter(os.Stdout)
+		cli.NoError(reverseHexDump(reader, writer), "unable to reverse hex dump")
+		cli.NoError(writer.Flush(), "unable to write output")
+
+		return
+	}
+
+	if *fromStdIn {
+		cli.EnsureNoCodecFlag(inputCodec, "-in")
+
+		skip := parseByteCount(*skipFlag, "-skip", 0)
+		length := parseByteCount(*lengthFlag, "-length", -1)
+
+		if *dumpFlag {
+			writer := bufio.NewWriter(os.Stdout)
+			dumper := newHexDumper(writer, skip, *colsFlag, *groupFlag)
+
+			cli.ProcessInputBytesSlice(flags.Args(), skip, length, cli.ChunkAlignment(*colsFlag), dumper.dump)
+			cli.NoError(writer.Flush(), "unable to write output")
+
+			return
+		}
+
+		cli.ProcessInputBytesSlice(flags.Args(), skip, length, cli.ChunkAlignmentByte, func(bytes []byte) { fmt.Print(cli.EncodeHex(bytes)) })
+		fmt.Println()
+
+		return
+	}
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), func(element string) (string, string, error) {
+		return toHex(element, inputCodec)
+	})
+}
+
+func toHex(element string, inputCodec *cli.Codec) (string, string, error) {
+	if element == "" {
+		return "", "", nil
+	}
+
+	if *reversedFourFlag || *reversedEightFlag {
+		count := 8
+		if *reversedFourFlag {
+			count = 4
+		}
+
+		bytes, err := cli.ParseReversedIntegerToBytes(element, count)
+		if err != nil {
+			return "", "", err
+		}
+
+		return cli.EncodeHex(bytes), inputCodec.Name, nil
+	}
+
+	return cli.Convert(element, inputCodec, hexCodec)
+}
+
+func parseByteCount(in string, flag string, defaultValue int64) int64 {
+	if in == "" {
+		return defaultValue
+	}
+
+	value, err := cli.ParseNumber(in)
+	cli.NoError(err, "invalid %s value", flag)
+	cli.Ensure(value.Sign() >= 0 && value.IsInt64() && value.Int64() < math.MaxInt64, "Flag %s must be a positive byte count", flag)
+
+	return value.Int64()
+}
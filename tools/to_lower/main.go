@@ -0,0 +1,25 @@
+package tolower
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_lower", flag.ExitOnError)
+
+// Tool is to_lower runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_lower", Short: "Transforms input to lower case", Main: Main}
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), toLower)
+}
+
+func toLower(element string) (string, string, error) {
+	return strings.ToLower(element), "", nil
+}
@@ -0,0 +1,17 @@
+package tolower
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dashedInputAfterTerminator(t *testing.T) {
+	require.NoError(t, flags.Parse([]string{"--", "-Foo"}))
+	require.Equal(t, []string{"-Foo"}, flags.Args())
+
+	out, _, err := toLower(flags.Arg(0))
+	require.NoError(t, err)
+	assert.Equal(t, "-foo", out)
+}
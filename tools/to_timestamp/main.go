@@ -0,0 +1,60 @@
+package totimestamp
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_timestamp", flag.ExitOnError)
+
+// Tool is to_timestamp runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_timestamp", Short: "Converts input to Unix timestamp", Main: Main}
+
+var asUnixSeconds = flags.Bool("s", false, "Format timestamp as Unix seconds")
+var asUnixMillis = flags.Bool("ms", false, "Format timestamp as Unix milliseconds")
+var asUnixMicros = flags.Bool("us", false, "Format timestamp as Unix microseconds")
+var asUnixNanos = flags.Bool("ns", false, "Format timestamp as Unix nanoseconds")
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	cli.RegisterTimezoneRegionsFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), toTimestamp)
+}
+
+var _, localOffset = time.Now().Zone()
+
+func toTimestamp(element string) (out string, kind string, err error) {
+	parsed, trace, ok := cli.ExplainDateLikeInput(element, cli.DateLikeHintNone, time.Local)
+	if !ok {
+		if trace.Err != nil {
+			return "", "", fmt.Errorf("unable to interpret %q as a date: %w", element, trace.Err)
+		}
+
+		return "", "", fmt.Errorf("unable to interpret %q as a date", element)
+	}
+
+	return strconv.FormatInt(toTimestampValue(parsed), 10), strings.ToLower(trace.ParsedFrom.String()), nil
+}
+
+func toTimestampValue(in time.Time) int64 {
+	switch {
+	case *asUnixSeconds:
+		return in.Unix()
+	case *asUnixMillis:
+		return in.UnixMilli()
+	case *asUnixMicros:
+		return in.UnixMicro()
+	case *asUnixNanos:
+		return in.UnixNano()
+	}
+
+	return in.Unix()
+}
@@ -0,0 +1,25 @@
+package toupper
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/streamingfast/tooling/cli"
+)
+
+var flags = flag.NewFlagSet("to_upper", flag.ExitOnError)
+
+// Tool is to_upper runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_upper", Short: "Transforms input to upper case", Main: Main}
+
+func Main() {
+	cli.RegisterConverterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cli.ConvertArguments(cli.NewArgumentScanner(flags.Args()), toUpper)
+}
+
+func toUpper(element string) (string, string, error) {
+	return strings.ToUpper(element), "", nil
+}
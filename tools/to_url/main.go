@@ -0,0 +1,100 @@
+package tourl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	. "github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/tooling/cli"
+)
+
+// Tool is to_url runnable as its own binary or as a subcommand of `sftool`.
+var Tool = cli.Tool{Name: "to_url", Short: "Encodes or decodes input from/to URL safe format", Main: Main}
+
+var version = "dev"
+
+func Main() {
+	Run(
+		"to_url [-d|--decode] [-e|--encode] <input>...",
+		"Encode or decode input(s) from/to URL safe format using heuristics by default to detect the way to use between decode/encode",
+		Description(`
+			The heuristics to decide if the inputs shall be decoded are:
+			- If the input contains %, it is considered as URL encoded and will be decoded
+
+			The heuristics to decide if the inputs shall be encoded are:
+			- All other cases, e.g. input does not contain %
+
+			You can force the process to decode or encode using the -d or -e flags.
+		`),
+		Flags(func(flags *pflag.FlagSet) {
+			flags.BoolP("decode", "d", false, "Disables auto-detection and forces process to decode the receive input(s), mutually exclusive with -encode")
+			flags.BoolP("encode", "e", false, "Disables auto-detection and forces process to encode the receive input(s), mutually exclusive with -decode")
+			cli.RegisterConverterFlags(flags)
+		}),
+		Example(`
+			# Decode the following URL encoded string (default heuristics kicked in due to presence of %)
+			to_url McGsrZDRZazAzbG9lRzFlVzUwcz0%3D
+
+			# If you want to encode %, you need to force it
+			to_url --encode Test_100%
+		`),
+		ArbitraryArgs(),
+
+		ConfigureVersion(version),
+		ConfigureViper("TO_URL"),
+
+		Execute(execute),
+	)
+}
+
+func execute(cmd *cobra.Command, args []string) error {
+	forcedDecode := sflags.MustGetBool(cmd, "decode")
+	forcedEncode := sflags.MustGetBool(cmd, "encode")
+
+	Ensure(!(forcedEncode && forcedDecode), "Cannot use --decode and --encode at the same time")
+
+	cli.ConvertArguments(cli.NewArgumentScanner(args), func(element string) (string, string, error) {
+		return toUrl(element, forcedDecode, forcedEncode)
+	})
+
+	return nil
+}
+
+func toUrl(element string, forcedDecode, forcedEncode bool) (string, string, error) {
+	if element == "" {
+		return "", "", nil
+	}
+
+	if forcedDecode {
+		out, err := toDecodedUrl(element)
+		return out, "decoded", err
+	}
+
+	if forcedEncode {
+		return toEncodedUrl(element), "encoded", nil
+	}
+
+	if strings.Contains(element, "%") {
+		out, err := toDecodedUrl(element)
+		return out, "decoded", err
+	}
+
+	return element, "", nil
+}
+
+func toEncodedUrl(element string) string {
+	return url.QueryEscape(element)
+}
+
+func toDecodedUrl(element string) (string, error) {
+	out, err := url.QueryUnescape(element)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode %q as URL: %w", element, err)
+	}
+
+	return out, nil
+}